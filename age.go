@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// AgeBucket is a named range of model ages. A model falls into the first
+// bucket whose MaxAge is at least its age; models older than every bucket
+// are labeled "older".
+type AgeBucket struct {
+	Label  string `json:"label"`   // Label shown next to the model, e.g. "this week"
+	MaxAge string `json:"max_age"` // Upper bound such as "24h" or "7d"
+}
+
+// defaultAgeBuckets are used when the config defines none.
+var defaultAgeBuckets = []AgeBucket{
+	{Label: "today", MaxAge: "1d"},
+	{Label: "this week", MaxAge: "7d"},
+	{Label: "this month", MaxAge: "30d"},
+}
+
+// parseAge parses a duration that may also use a "d" (days) suffix.
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// validateAgeBuckets checks that every bucket has a label and a valid,
+// increasing maximum age.
+func validateAgeBuckets(buckets []AgeBucket) error {
+	var prev time.Duration
+	for i, b := range buckets {
+		if b.Label == "" {
+			return fmt.Errorf("age_buckets[%d]: label is required", i)
+		}
+		d, err := parseAge(b.MaxAge)
+		if err != nil {
+			return fmt.Errorf("age_buckets[%d]: %w", i, err)
+		}
+		if d <= prev {
+			return fmt.Errorf("age_buckets[%d]: max_age must be greater than the previous bucket's", i)
+		}
+		prev = d
+	}
+	return nil
+}
+
+// ageBucket returns the label of the bucket modified falls into, relative to
+// now, and its index (len(buckets) for "older"). Unknown times yield "".
+func ageBucket(buckets []AgeBucket, modified, now time.Time) (string, int) {
+	if modified.IsZero() {
+		return "", -1
+	}
+	age := now.Sub(modified)
+	for i, b := range buckets {
+		if d, err := parseAge(b.MaxAge); err == nil && age <= d {
+			return b.Label, i
+		}
+	}
+	return "older", len(buckets)
+}
+
+// ageBuckets returns the configured age buckets or the defaults.
+func (a *App) ageBuckets() []AgeBucket {
+	if len(a.config.AgeBuckets) > 0 {
+		return a.config.AgeBuckets
+	}
+	return defaultAgeBuckets
+}
+
+// ageLabel returns the colored age bucket label for a model modified at t:
+// the newest bucket uses the accent color and "older" the alert color.
+func (a *App) ageLabel(t, now time.Time) string {
+	buckets := a.ageBuckets()
+	label, i := ageBucket(buckets, t, now)
+	switch {
+	case label == "":
+		return ""
+	case i == 0:
+		return a.theme.paint(a.theme.accent, label)
+	case i == len(buckets):
+		return a.theme.paint(a.theme.alert, label)
+	default:
+		return label
+	}
+}
+
+// onToggleAge shows or hides the age bucket of each installed model.
+func (a *App) onToggleAge(_ *gocui.Gui, _ *gocui.View) error {
+	a.showAge = !a.showAge
+	a.drawInstalled()
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// Default auto-refresh intervals of the running and installed panes.
+const (
+	defaultRefreshRunning   = 5 * time.Second
+	defaultRefreshInstalled = 30 * time.Second
+)
+
+// autoRefresh holds the schedule of the periodic background refresh.
+type autoRefresh struct {
+	nextRunning   time.Time // When the running pane is refreshed next
+	nextInstalled time.Time // When the installed pane is refreshed next
+	paused        bool      // Whether automatic refreshes are suspended
+	busy          bool      // Whether an automatic refresh is in flight
+}
+
+// interval parses an auto-refresh interval setting: empty means def and
+// "0" disables the refresh.
+func interval(name, value string, def time.Duration) (time.Duration, error) {
+	switch value {
+	case "":
+		return def, nil
+	case "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("%s: must be at least 1s", name)
+	}
+	return d, nil
+}
+
+// refreshIntervals returns the configured auto-refresh intervals of the
+// running and installed panes, 0 where disabled.
+func (c *Config) refreshIntervals() (running, installed time.Duration, err error) {
+	if running, err = interval("refresh_running", c.RefreshRunning, defaultRefreshRunning); err != nil {
+		return 0, 0, err
+	}
+	if installed, err = interval("refresh_installed", c.RefreshInstalled, defaultRefreshInstalled); err != nil {
+		return 0, 0, err
+	}
+	return running, installed, nil
+}
+
+// monitorRefresh ticks the auto-refresh schedule every second for the
+// lifetime of the program, which also keeps the countdown current.
+func (a *App) monitorRefresh() {
+	now := time.Now()
+	running, installed, _ := a.config.refreshIntervals()
+	a.auto.nextRunning, a.auto.nextInstalled = now.Add(running), now.Add(installed)
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.safeUpdate(func(g *gocui.Gui) error {
+				a.tickRefresh(g, time.Now())
+				return nil
+			})
+		}
+	}()
+}
+
+// tickRefresh starts the refreshes that are due at now and updates the
+// countdown. Nothing is refreshed while paused or disconnected; the health
+// check refreshes everything once the server is back.
+func (a *App) tickRefresh(g *gocui.Gui, now time.Time) {
+	defer a.updateStatusTitle(g)
+	running, installed, _ := a.config.refreshIntervals()
+	if a.auto.paused || a.auto.busy || a.offline || !a.loaded {
+		return
+	}
+	dueRunning := running > 0 && !now.Before(a.auto.nextRunning)
+	dueInstalled := installed > 0 && !now.Before(a.auto.nextInstalled)
+	if dueRunning {
+		a.auto.nextRunning = now.Add(running)
+	}
+	if dueInstalled {
+		a.auto.nextInstalled = now.Add(installed)
+	}
+	if dueRunning || dueInstalled {
+		a.refreshQuietly(dueRunning, dueInstalled)
+	}
+}
+
+// refreshQuietly fetches the running and/or installed models in a background
+// goroutine and updates the panes, sharing refreshAll's result handling.
+// Unlike refreshAll nothing is logged, and the error hook does not run,
+// except for an error that was not already showing in the pane.
+func (a *App) refreshQuietly(running, installed bool) {
+	a.auto.busy = true
+	client := a.client
+	r := refreshResult{gen: a.hostGen, skipInstalled: !installed, skipRunning: !running, quiet: true}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if installed {
+			a.loadingInstalled.Add(1)
+			r.installed, r.installedErr = client.ListLocalModels(ctx)
+			a.loadingInstalled.Add(-1)
+		}
+		if running {
+			a.loadingRunning.Add(1)
+			r.running, r.runningErr = client.ListRunning(ctx)
+			a.loadingRunning.Add(-1)
+		}
+		a.safeUpdate(func(*gocui.Gui) error {
+			a.auto.busy = false
+			a.applyRefresh(r)
+			return nil
+		})
+	}()
+}
+
+// refreshCountdown describes the auto-refresh state for the status title:
+// the time until the next refresh, or that it is paused. It is empty when
+// automatic refresh is disabled.
+func (a *App) refreshCountdown() string {
+	running, installed, _ := a.config.refreshIntervals()
+	if running == 0 && installed == 0 {
+		return ""
+	}
+	if a.auto.paused {
+		return "auto-refresh paused"
+	}
+	next := a.auto.nextRunning
+	if running == 0 || (installed > 0 && a.auto.nextInstalled.Before(next)) {
+		next = a.auto.nextInstalled
+	}
+	secs := int(time.Until(next).Round(time.Second) / time.Second)
+	if secs < 0 {
+		secs = 0
+	}
+	return fmt.Sprintf("refresh in %ds", secs)
+}
+
+// onToggleAutoRefresh pauses or resumes the automatic refresh. Resuming
+// refreshes the running pane right away.
+func (a *App) onToggleAutoRefresh(g *gocui.Gui, _ *gocui.View) error {
+	a.auto.paused = !a.auto.paused
+	if a.auto.paused {
+		a.logf("Auto-refresh paused")
+	} else {
+		a.logf("Auto-refresh resumed")
+		a.auto.nextRunning = time.Now()
+	}
+	a.tickRefresh(g, time.Now())
+	return nil
+}
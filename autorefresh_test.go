@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestQuietRefreshReportsLikeRefreshAll checks that an automatic refresh is
+// counted in the metrics and runs the error hook like a manual one, but
+// reports a repeated error only once and leaves the list it did not fetch
+// alone.
+func TestQuietRefreshReportsLikeRefreshAll(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook command uses sh")
+	}
+	a := newApp("http://localhost:11434")
+	a.loaded = true
+	a.setInstalled(models("llama3.2:latest", "qwen2.5:7b"))
+	hookOut := filepath.Join(t.TempDir(), "errors")
+	a.config.Hooks = map[string]string{eventError: `echo "$OLAZYLLAMA_ERROR" >> ` + hookOut}
+
+	refreshes, failures := metricRefreshes.Value(), metricRefreshErrors.Value()
+	fail := refreshResult{gen: a.hostGen, runningErr: errors.New("boom"), skipInstalled: true, quiet: true}
+	a.applyRefresh(fail)
+	a.applyRefresh(fail)
+
+	if got := metricRefreshes.Value() - refreshes; got != 2 {
+		t.Errorf("refreshes metric grew by %d, want 2", got)
+	}
+	if got := metricRefreshErrors.Value() - failures; got != 2 {
+		t.Errorf("refresh errors metric grew by %d, want 2", got)
+	}
+	if got := strings.Count(strings.Join(a.status(), "\n"), "Running: boom"); got != 1 {
+		t.Errorf("error logged %d times, want once: %q", got, a.status())
+	}
+	if len(a.installed) != 2 || a.installedErr != nil {
+		t.Errorf("got %d installed models and error %v, want the unfetched list kept", len(a.installed), a.installedErr)
+	}
+
+	// The hook runs in the background; give a second run time to show up.
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if fi, err := os.Stat(hookOut); err == nil && fi.Size() > 0 {
+			break
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	if out, _ := os.ReadFile(hookOut); string(out) != "boom\n" {
+		t.Errorf("error hook output %q, want it run once with the error", out)
+	}
+
+	a.applyRefresh(refreshResult{gen: a.hostGen, running: models("llama3.2:latest"), skipInstalled: true, quiet: true})
+	if a.runningErr != nil || len(a.running) != 1 {
+		t.Errorf("runningErr=%v with %d running models, want the recovered list", a.runningErr, len(a.running))
+	}
+	if last := a.status()[len(a.status())-1]; last == "Refreshed" {
+		t.Error("automatic refresh logged \"Refreshed\"")
+	}
+}
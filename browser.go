@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// openerCommand returns the command that opens a URL in the default browser
+// on the current platform.
+func openerCommand(url string) []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open", url}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler", url}
+	default:
+		return []string{"xdg-open", url}
+	}
+}
+
+// openURL opens url in the default browser without waiting for it.
+func openURL(url string) error {
+	args := openerCommand(url)
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return fmt.Errorf("%s not found", args[0])
+	}
+	cmd := exec.Command(path, args[1:]...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// openModelPage opens the ollama.com library page of the named model. If no
+// browser can be started the URL is logged instead, to be opened by hand.
+func (a *App) openModelPage(name string) {
+	url, err := a.libraryClient.PageURL(name)
+	if errors.Is(err, ollama.ErrNotSupported) {
+		a.logf("%s is not an ollama.com model", displayName(name))
+		return
+	}
+	if err != nil {
+		a.logErr("Open page", err)
+		return
+	}
+	if err := openURL(url); err != nil {
+		a.logf("Open page: %v; it is at %s", err, url)
+		return
+	}
+	a.logf("Opened %s", url)
+}
+
+// onOpenModelPage opens the library page of the model selected in v, the
+// installed or running pane.
+func (a *App) onOpenModelPage(_ *gocui.Gui, v *gocui.View) error {
+	if m := a.selectedIn(v); m != nil {
+		a.openModelPage(m.Name)
+	}
+	return nil
+}
+
+// onLibraryOpenPage opens the library page of the model shown in the
+// library overlay: the selected search result, or the model whose tags are
+// listed.
+func (a *App) onLibraryOpenPage(_ *gocui.Gui, _ *gocui.View) error {
+	b := a.library
+	switch {
+	case b == nil || b.loading:
+	case b.model != "":
+		a.openModelPage(b.model)
+	case b.selected < len(b.results):
+		a.openModelPage(b.results[b.selected].Name)
+	}
+	return nil
+}
@@ -0,0 +1,436 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"olazyllama/internal/ollama"
+)
+
+// Config holds user settings loaded from the configuration file.
+type Config struct {
+	Host         string            `json:"host,omitempty"`          // Ollama server URL
+	Backend      string            `json:"backend,omitempty"`       // Server API: "ollama" (default) or "openai" for OpenAI-compatible servers
+	Theme        string            `json:"theme,omitempty"`         // Theme name ("default" or "mono")
+	Keymap       string            `json:"keymap,omitempty"`        // Key binding set: "vim" adds vim-style keys to the default
+	Keybindings  map[string]string `json:"keybindings,omitempty"`   // Keys of actions by description, e.g. "delete": "D"; several keys separated by spaces
+	KeepAlive    []KeepAliveRule   `json:"keep_alive,omitempty"`    // Per-model keep-alive defaults, first match wins
+	DefaultModel string            `json:"default_model,omitempty"` // Model preloaded by the quick-action key
+	Hooks        map[string]string `json:"hooks,omitempty"`         // Shell commands run on events, keyed by event name
+	HideSize     bool              `json:"hide_size,omitempty"`     // Hide the size column in the installed pane
+	AgeBuckets   []AgeBucket       `json:"age_buckets,omitempty"`   // Age ranges for the age display, newest first
+	IdleTimeout  string            `json:"idle_timeout,omitempty"`  // Longest silence tolerated on progress streams, e.g. "2m"; "0" disables
+
+	RefreshRunning   string `json:"refresh_running,omitempty"`   // Auto-refresh interval of the running pane, default "5s"; "0" disables
+	RefreshInstalled string `json:"refresh_installed,omitempty"` // Auto-refresh interval of the installed pane, default "30s"; "0" disables
+
+	CompactRunning bool `json:"compact_running,omitempty"` // Show only names in the running pane
+	DisableMouse   bool `json:"disable_mouse,omitempty"`   // Leave the mouse to the terminal, e.g. for selecting text
+
+	Split       int    `json:"split,omitempty"`        // Width of the installed pane in percent of the screen; 0 means 50
+	RunningPane string `json:"running_pane,omitempty"` // "auto" hides the running and tasks panes while nothing is loaded, "hidden" always
+	HideStatus  bool   `json:"hide_status,omitempty"`  // Hide the status pane
+
+	InsecureRegistries []string `json:"insecure_registries,omitempty"` // Registry hosts reached over HTTP or unverified TLS
+
+	Token     string            `json:"token,omitempty"`      // Bearer token sent to the server; "$NAME" reads it from the environment
+	BasicAuth *BasicAuth        `json:"basic_auth,omitempty"` // Basic auth credentials sent to the server
+	Headers   map[string]string `json:"headers,omitempty"`    // Extra headers sent with every request to the server
+
+	Proxy string     `json:"proxy,omitempty"` // Proxy URL for all requests, overriding HTTP_PROXY/HTTPS_PROXY
+	TLS   *TLSConfig `json:"tls,omitempty"`   // Certificates used for HTTPS servers
+
+	MaxTransfers int `json:"max_transfers,omitempty"` // Concurrent pulls, pushes and creates; 0 uses the default, -1 removes the cap
+}
+
+// TLSConfig holds the certificate settings for HTTPS connections.
+type TLSConfig struct {
+	CAFile             string `json:"ca_file,omitempty"`              // PEM bundle of additional trusted CAs
+	CertFile           string `json:"cert_file,omitempty"`            // PEM client certificate for mutual TLS
+	KeyFile            string `json:"key_file,omitempty"`             // PEM private key of the client certificate
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"` // Skip server certificate verification (unsafe)
+}
+
+// BasicAuth holds HTTP basic authentication credentials.
+type BasicAuth struct {
+	User     string `json:"user"`     // User name
+	Password string `json:"password"` // Password; "$NAME" reads it from the environment
+}
+
+// KeepAliveRule maps a model name or glob pattern to a keep-alive duration.
+// Patterns use path.Match syntax, e.g. "deepseek-r1:*" or "*:1b".
+type KeepAliveRule struct {
+	Model     string `json:"model"`      // Model name or glob pattern
+	KeepAlive string `json:"keep_alive"` // Duration such as "30s" or "1h", or seconds; negative keeps the model loaded
+}
+
+// Backends selectable with the backend setting.
+const (
+	BackendOllama = "ollama" // Native Ollama API
+	BackendOpenAI = "openai" // OpenAI-compatible API (llama.cpp server, vLLM, LM Studio)
+)
+
+// ResolveBackend determines the server API from the --backend flag or the
+// backend configured in cfg, defaulting to Ollama.
+func ResolveBackend(flag string, cfg *Config) string {
+	if flag != "" {
+		return flag
+	}
+	if cfg != nil && cfg.Backend != "" {
+		return cfg.Backend
+	}
+	return BackendOllama
+}
+
+// validateBackend checks that name is a known backend.
+func validateBackend(name string) error {
+	switch name {
+	case "", BackendOllama, BackendOpenAI:
+		return nil
+	}
+	return fmt.Errorf("backend: unknown backend %q (want %q or %q)", name, BackendOllama, BackendOpenAI)
+}
+
+// defaultBaseURL is the address of a default local Ollama server.
+const defaultBaseURL = "http://localhost:11434"
+
+// ResolveBaseURL determines the Ollama server URL. It checks, in order, the
+// --host flag, the host configured in cfg, the OLLAMA_HOST environment
+// variable, and finally falls back to the default local server. Each of
+// them may be given in any form OLLAMA_HOST accepts.
+func ResolveBaseURL(flag string, cfg *Config) string {
+	if strings.TrimSpace(flag) != "" {
+		return parseOllamaHost(flag)
+	}
+	if cfg != nil && strings.TrimSpace(cfg.Host) != "" {
+		return parseOllamaHost(cfg.Host)
+	}
+	if env := os.Getenv("OLLAMA_HOST"); strings.TrimSpace(env) != "" {
+		return parseOllamaHost(env)
+	}
+	return defaultBaseURL
+}
+
+// parseOllamaHost converts an OLLAMA_HOST value into a base URL using the
+// same rules as the ollama CLI: surrounding quotes are ignored, the scheme
+// defaults to http, and a missing host or port defaults to 127.0.0.1 and
+// 11434 (or 80/443 for an explicit http/https scheme). Values such as
+// "0.0.0.0", ":8080", "example.com" and "https://example.com/ollama" are
+// accepted; an invalid port yields the default address.
+func parseOllamaHost(s string) string {
+	s = strings.Trim(strings.TrimSpace(s), "\"'")
+	defaultPort := "11434"
+	scheme, hostport, ok := strings.Cut(s, "://")
+	switch {
+	case !ok:
+		scheme, hostport = "http", s
+	case scheme == "unix":
+		return s
+	case scheme == "http":
+		defaultPort = "80"
+	case scheme == "https":
+		defaultPort = "443"
+	}
+	hostport, prefix, _ := strings.Cut(hostport, "/")
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host, port = "127.0.0.1", defaultPort
+		if ip := net.ParseIP(strings.Trim(hostport, "[]")); ip != nil {
+			host = ip.String()
+		} else if hostport != "" {
+			host = hostport
+		}
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return "http://127.0.0.1:11434"
+	}
+	u := url.URL{Scheme: scheme, Host: net.JoinHostPort(host, port)}
+	if prefix != "" {
+		u.Path = "/" + prefix
+	}
+	return u.String()
+}
+
+// ResolveModelsDir determines the directory the Ollama server stores models
+// in. The --models-dir flag takes precedence over $OLLAMA_MODELS and the
+// default ~/.ollama/models.
+func ResolveModelsDir(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return ollama.DefaultModelsDir()
+}
+
+// checkModelsDir verifies that dir exists, is a directory and can be read.
+func checkModelsDir(dir string) error {
+	if dir == "" {
+		return errors.New("models directory unknown")
+	}
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s: not a directory", dir)
+	}
+	if _, err := f.Readdirnames(1); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// defaultConfigPath returns the standard location of the configuration file,
+// or an empty string if the user config directory cannot be determined.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "olazyllama", "config.json")
+}
+
+// loadConfig reads and validates the configuration file at p.
+// A missing file is not an error and yields an empty configuration.
+func loadConfig(p string) (*Config, error) {
+	cfg := &Config{}
+	if p == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+	return cfg, nil
+}
+
+// saveConfig writes cfg to p as indented JSON, creating the parent directory
+// if needed. A new file is only readable by the user, since it may hold a
+// token or password; an existing file keeps its mode.
+func saveConfig(p string, cfg *Config) error {
+	if p == "" {
+		return errors.New("no config path")
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, append(data, '\n'), 0o600)
+}
+
+// validate checks that all configured values are well-formed.
+func (c *Config) validate() error {
+	if err := validateHooks(c.Hooks); err != nil {
+		return err
+	}
+	if err := validateAgeBuckets(c.AgeBuckets); err != nil {
+		return err
+	}
+	if _, err := c.idleTimeout(); err != nil {
+		return err
+	}
+	if _, _, err := c.refreshIntervals(); err != nil {
+		return err
+	}
+	if c.Token != "" && c.BasicAuth != nil {
+		return errors.New("token and basic_auth are mutually exclusive")
+	}
+	if c.BasicAuth != nil && c.BasicAuth.User == "" {
+		return errors.New("basic_auth: user is required")
+	}
+	for name := range c.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("headers: invalid header name %q", name)
+		}
+	}
+	if err := validateBackend(c.Backend); err != nil {
+		return err
+	}
+	if err := c.validateLayout(); err != nil {
+		return err
+	}
+	if err := validateKeymap(c.Keymap); err != nil {
+		return err
+	}
+	if err := validateKeybindings(c.Keybindings); err != nil {
+		return err
+	}
+	if _, err := c.proxyURL(); err != nil {
+		return err
+	}
+	if c.MaxTransfers < -1 {
+		return fmt.Errorf("max_transfers: %d is not allowed (use -1 for no limit)", c.MaxTransfers)
+	}
+	if c.TLS != nil && (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return errors.New("tls: cert_file and key_file must be set together")
+	}
+	for i, r := range c.KeepAlive {
+		if r.Model == "" {
+			return fmt.Errorf("keep_alive[%d]: model is required", i)
+		}
+		if _, err := path.Match(r.Model, ""); err != nil {
+			return fmt.Errorf("keep_alive[%d]: bad pattern %q: %w", i, r.Model, err)
+		}
+		if err := validateKeepAlive(r.KeepAlive); err != nil {
+			return fmt.Errorf("keep_alive[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateKeepAlive checks that s is a number of seconds or a duration.
+func validateKeepAlive(s string) error {
+	if _, err := strconv.Atoi(s); err == nil {
+		return nil
+	}
+	_, err := time.ParseDuration(s)
+	return err
+}
+
+// configureClient applies the client settings of c: the stream idle
+// timeout, proxy, transfer limit, TLS certificates, authentication and
+// extra headers. An error means the TLS files could not be loaded; the
+// other settings are applied regardless.
+func (c *Config) configureClient(client *ollama.Client) error {
+	client.IdleTimeout, _ = c.idleTimeout()
+	if proxy, _ := c.proxyURL(); proxy != nil {
+		client.SetProxy(proxy)
+	}
+	if c.MaxTransfers != 0 {
+		client.SetMaxTransfers(c.MaxTransfers)
+	}
+	var tlsErr error
+	if c.TLS != nil {
+		tlsErr = client.ConfigureTLS(c.tlsOptions())
+	}
+	client.Token = expandSecret(c.Token)
+	if c.BasicAuth != nil {
+		client.BasicAuth = &ollama.BasicAuth{User: c.BasicAuth.User, Password: expandSecret(c.BasicAuth.Password)}
+	}
+	if len(c.Headers) > 0 {
+		client.Headers = make(http.Header, len(c.Headers))
+		for k, v := range c.Headers {
+			client.Headers.Set(k, v)
+		}
+	}
+	return tlsErr
+}
+
+// configureLibrary applies the proxy and TLS settings of c to the client of
+// the public model library, which is reached through the same network. An
+// error means the TLS files could not be loaded.
+func (c *Config) configureLibrary(library *ollama.Library) error {
+	if proxy, _ := c.proxyURL(); proxy != nil {
+		library.SetProxy(proxy)
+	}
+	if c.TLS == nil {
+		return nil
+	}
+	return library.ConfigureTLS(c.tlsOptions())
+}
+
+// tlsOptions returns the configured TLS settings. c.TLS must not be nil.
+func (c *Config) tlsOptions() ollama.TLSOptions {
+	return ollama.TLSOptions{
+		CAFile:             c.TLS.CAFile,
+		CertFile:           c.TLS.CertFile,
+		KeyFile:            c.TLS.KeyFile,
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+	}
+}
+
+// proxyURL parses the configured proxy URL, returning nil when none is set
+// so that the environment's proxy settings apply.
+func (c *Config) proxyURL() (*url.URL, error) {
+	if c.Proxy == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(c.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy: unsupported scheme %q (want http, https or socks5)", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy: missing host in %q", c.Proxy)
+	}
+	return u, nil
+}
+
+// expandSecret returns the value of the environment variable NAME if s is
+// "$NAME", so that secrets need not be stored in the config file.
+func expandSecret(s string) string {
+	if name, ok := strings.CutPrefix(s, "$"); ok && name != "" {
+		return os.Getenv(name)
+	}
+	return s
+}
+
+// idleTimeout returns the configured stream idle timeout, or the client
+// default when none is set.
+func (c *Config) idleTimeout() (time.Duration, error) {
+	switch c.IdleTimeout {
+	case "":
+		return ollama.DefaultIdleTimeout, nil
+	case "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.IdleTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("idle_timeout: %w", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("idle_timeout: must not be negative")
+	}
+	return d, nil
+}
+
+// keepAliveFor returns the keep-alive configured for the named model.
+// A pattern without a tag also matches the model's ":latest" tag. An empty
+// result means no rule matched and the server default should be used.
+func (c *Config) keepAliveFor(name string) string {
+	short := strings.TrimSuffix(name, ":latest")
+	for _, r := range c.KeepAlive {
+		if ok, _ := path.Match(r.Model, name); ok {
+			return r.KeepAlive
+		}
+		if ok, _ := path.Match(r.Model, short); ok {
+			return r.KeepAlive
+		}
+	}
+	return ""
+}
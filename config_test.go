@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"olazyllama/internal/ollama"
+)
+
+// TestSaveConfigMode checks that a new config file is private to the user
+// and that rewriting an existing one keeps the mode it was given.
+func TestSaveConfigMode(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "olazyllama", "config.json")
+	cfg := &Config{Token: "secret"}
+	if err := saveConfig(p, cfg); err != nil {
+		t.Fatalf("saveConfig: %v", err)
+	}
+	if got := fileMode(t, p); got != 0o600 {
+		t.Errorf("new config mode = %v, want 0600", got)
+	}
+
+	if err := os.Chmod(p, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveConfig(p, cfg); err != nil {
+		t.Fatalf("saveConfig: %v", err)
+	}
+	if got := fileMode(t, p); got != 0o640 {
+		t.Errorf("rewritten config mode = %v, want 0640 kept", got)
+	}
+}
+
+// fileMode returns the permission bits of the file at p.
+func fileMode(t *testing.T, p string) os.FileMode {
+	t.Helper()
+	fi, err := os.Stat(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fi.Mode().Perm()
+}
+
+// TestResolveBaseURL checks that the --host flag and the configured host
+// accept the same forms as OLLAMA_HOST, in order of precedence.
+func TestResolveBaseURL(t *testing.T) {
+	tests := []struct {
+		name, flag, cfg, env string
+		want                 string
+	}{
+		{"default", "", "", "", "http://localhost:11434"},
+		{"env bare host", "", "", "example.com", "http://example.com:11434"},
+		{"flag bare host", "example.com", "", "", "http://example.com:11434"},
+		{"flag port only", ":8080", "", "", "http://127.0.0.1:8080"},
+		{"flag full URL", "https://example.com/ollama", "", "", "https://example.com:443/ollama"},
+		{"flag unix socket", "unix:///run/ollama.sock", "", "", "unix:///run/ollama.sock"},
+		{"config bare address", "", "0.0.0.0", "", "http://0.0.0.0:11434"},
+		{"config quoted", "", `"gpu-box:11500"`, "", "http://gpu-box:11500"},
+		{"config unix socket", "", "unix:///tmp/ollama.sock", "", "unix:///tmp/ollama.sock"},
+		{"flag over config", "flag-host", "config-host", "env-host", "http://flag-host:11434"},
+		{"config over env", "", "config-host", "env-host", "http://config-host:11434"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OLLAMA_HOST", tt.env)
+			if got := ResolveBaseURL(tt.flag, &Config{Host: tt.cfg}); got != tt.want {
+				t.Errorf("ResolveBaseURL(%q, %q) with OLLAMA_HOST=%q = %q, want %q", tt.flag, tt.cfg, tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConfigureLibraryProxy checks that library requests go through the
+// configured proxy rather than straight to the site.
+func TestConfigureLibraryProxy(t *testing.T) {
+	proxied := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r.URL.String()
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<ul id="searchresults"></ul>`))
+	}))
+	defer proxy.Close()
+
+	library := ollama.NewLibrary()
+	library.BaseURL = "http://library.invalid"
+	cfg := &Config{Proxy: proxy.URL}
+	if err := cfg.configureLibrary(library); err != nil {
+		t.Fatalf("configureLibrary: %v", err)
+	}
+	if _, err := library.Search(context.Background(), "llama"); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got, want := <-proxied, "http://library.invalid/search?q=llama"; got != want {
+		t.Errorf("proxy got request for %q, want %q", got, want)
+	}
+}
+
+// TestConfigureLibraryTLS checks that the TLS settings reach the library
+// client and that unreadable certificate files are reported.
+func TestConfigureLibraryTLS(t *testing.T) {
+	site := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<ul id="searchresults"></ul>`))
+	}))
+	defer site.Close()
+
+	library := ollama.NewLibrary()
+	library.BaseURL = site.URL
+	if _, err := library.Search(context.Background(), "llama"); err == nil {
+		t.Fatal("Search trusted the test certificate without configuration")
+	}
+	cfg := &Config{TLS: &TLSConfig{InsecureSkipVerify: true}}
+	if err := cfg.configureLibrary(library); err != nil {
+		t.Fatalf("configureLibrary: %v", err)
+	}
+	if _, err := library.Search(context.Background(), "llama"); err != nil {
+		t.Errorf("Search with insecure_skip_verify: %v", err)
+	}
+
+	cfg = &Config{TLS: &TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}}
+	if err := cfg.configureLibrary(ollama.NewLibrary()); err == nil || !strings.Contains(err.Error(), "CA bundle") {
+		t.Errorf("configureLibrary with a missing CA file: error %v, want it reported", err)
+	}
+}
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+)
+
+// askConfirm opens the confirmation dialog with the given message. onYes
+// runs on the GUI goroutine if the user confirms; declining simply closes
+// the dialog.
+func (a *App) askConfirm(g *gocui.Gui, message string, onYes func(*gocui.Gui) error) {
+	a.confirm.Ask(g, message, viewInstalled, onYes)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// onCopyModel prompts for a destination name and copies the selected
+// installed model to it.
+func (a *App) onCopyModel(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	source := m.Name
+	a.askInput(g, "Copy "+displayName(source)+" to", source, func(g *gocui.Gui, dest string) error {
+		switch {
+		case dest == "" || dest == source:
+			a.logf("Copy canceled")
+		case a.isInstalled(dest):
+			a.askConfirm(g, "Overwrite "+displayName(dest)+"?", func(*gocui.Gui) error {
+				a.copyModel(source, dest)
+				return nil
+			})
+		default:
+			a.copyModel(source, dest)
+		}
+		return nil
+	})
+	return nil
+}
+
+// copyModel copies source to dest in a background goroutine and refreshes
+// the model lists once done.
+func (a *App) copyModel(source, dest string) {
+	a.logf("Copying %s to %s...", source, dest)
+	ctx, finish := a.startTask("copy " + source + " to " + dest)
+	client := a.client
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		err := client.CopyModel(ctx, source, dest)
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Copy "+source, err)
+				a.runHook(eventError, hookEnv{Model: source, Error: err.Error()})
+				return nil
+			}
+			a.logf("Copied %s to %s", source, dest)
+			a.refreshAll()
+			return nil
+		})
+	}()
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// Steps of the guided create-model form.
+const (
+	createStepBase    = iota // Pick the base model
+	createStepName           // Enter the new model's name
+	createStepSystem         // Enter the system prompt
+	createStepConfirm        // Review the Modelfile and create
+)
+
+// createForm holds the state of the guided create-model form.
+type createForm struct {
+	step   int    // Current step
+	base   int    // Index into the installed display order of the base model
+	name   string // Name of the new model
+	system string // System prompt
+}
+
+// buildModelfile assembles a Modelfile deriving a model from base with the
+// given system prompt. Prompts containing quotes or newlines use the
+// triple-quoted form.
+func buildModelfile(base, system string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "FROM %s\n", base)
+	if system != "" {
+		if strings.ContainsAny(system, "\"\n") {
+			fmt.Fprintf(&b, "SYSTEM \"\"\"%s\"\"\"\n", strings.ReplaceAll(system, `"""`, `\"\"\"`))
+		} else {
+			fmt.Fprintf(&b, "SYSTEM \"%s\"\n", system)
+		}
+	}
+	return b.String()
+}
+
+// createBaseName returns the name of the base model picked in the form.
+func (a *App) createBaseName() string {
+	if a.create == nil || a.create.base < 0 || a.create.base >= len(a.order) {
+		return ""
+	}
+	return a.installed[a.order[a.create.base]].Name
+}
+
+// onStartCreate opens the guided create-model form, preselecting the
+// currently selected installed model as the base.
+func (a *App) onStartCreate(g *gocui.Gui, _ *gocui.View) error {
+	if len(a.order) == 0 {
+		a.logf("No installed models to derive from")
+		return nil
+	}
+	a.create = &createForm{step: createStepBase, base: a.selected}
+	return a.showCreateStep(g)
+}
+
+// layoutCreate keeps the create form, if open, centered and on top.
+func (a *App) layoutCreate(g *gocui.Gui) error {
+	if a.create == nil {
+		if _, err := g.View(viewCreate); err == nil {
+			return g.DeleteView(viewCreate)
+		}
+		return nil
+	}
+	maxX, maxY := g.Size()
+	x0, y0 := maxX/6, maxY/5
+	if _, err := g.SetView(viewCreate, x0, y0, maxX-x0-1, maxY-y0-1); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	_, err := g.SetViewOnTop(viewCreate)
+	return err
+}
+
+// showCreateStep (re)creates the form view for the current step.
+func (a *App) showCreateStep(g *gocui.Gui) error {
+	if err := g.DeleteView(viewCreate); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	if err := a.layoutCreate(g); err != nil {
+		return err
+	}
+	v, err := g.View(viewCreate)
+	if err != nil {
+		return err
+	}
+	f := a.create
+	v.Clear()
+	v.Editable = false
+	v.Highlight = false
+	v.Wrap = true
+	switch f.step {
+	case createStepBase:
+		v.Title = "Create model 1/4: base model (↑↓ pick, Enter next, Esc cancel)"
+		v.Wrap = false
+		v.Highlight = true
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		for _, idx := range a.order {
+			fmt.Fprintln(v, displayName(a.installed[idx].Name))
+		}
+		if err := showRow(v, f.base); err != nil {
+			return err
+		}
+		_, err = g.SetCurrentView(viewCreate)
+		return err
+	case createStepName:
+		v.Title = "Create model 2/4: new model name (Enter next, Esc back)"
+		v.Editable = true
+		fmt.Fprint(v, f.name)
+		v.SetCursor(len([]rune(f.name)), 0)
+	case createStepSystem:
+		v.Title = "Create model 3/4: system prompt (Enter next, Esc back)"
+		v.Editable = true
+		fmt.Fprint(v, f.system)
+		v.SetCursor(len([]rune(f.system)), 0)
+	case createStepConfirm:
+		v.Title = "Create model 4/4: review (Enter create, Esc back)"
+		fmt.Fprintf(v, "Create %s with this Modelfile:\n\n", f.name)
+		fmt.Fprint(v, buildModelfile(a.createBaseName(), f.system))
+		_, err = g.SetCurrentView(viewCreate)
+		return err
+	}
+	return a.focusEditable(g, viewCreate)
+}
+
+// createRequest builds the request for deriving name from base with the given
+// system prompt. Servers known to support structured creation get structured
+// fields; others, including servers of unknown version, get a Modelfile.
+func (a *App) createRequest(name, base, system string) ollama.CreateRequest {
+	if a.serverVersion != "" && ollama.SupportsFeature(a.serverVersion, ollama.FeatureStructuredCreate) {
+		return ollama.CreateRequest{Model: name, From: base, System: system}
+	}
+	return ollama.CreateRequest{Model: name, Modelfile: buildModelfile(base, system)}
+}
+
+// createInput returns the single-line text currently typed into the form.
+func createInput(v *gocui.View) string {
+	return strings.TrimSpace(strings.ReplaceAll(v.Buffer(), "\n", ""))
+}
+
+// onCreateNext validates the current step and advances the form; on the
+// last step it starts creating the model.
+func (a *App) onCreateNext(g *gocui.Gui, v *gocui.View) error {
+	f := a.create
+	if f == nil {
+		return nil
+	}
+	switch f.step {
+	case createStepBase:
+		_, cy := v.Cursor()
+		_, oy := v.Origin()
+		f.base = cy + oy
+	case createStepName:
+		f.name = createInput(v)
+		if f.name == "" {
+			a.logf("Enter a name for the new model")
+			return nil
+		}
+		if a.isInstalled(f.name) {
+			a.logf("%s already exists; pick another name", f.name)
+			return nil
+		}
+	case createStepSystem:
+		f.system = createInput(v)
+	case createStepConfirm:
+		base := a.createBaseName()
+		if err := a.closeCreate(g); err != nil {
+			return err
+		}
+		a.runCreate(ollama.CreateRequest{Model: f.name, Modelfile: buildModelfile(base, f.system)})
+		return nil
+	}
+	f.step++
+	return a.showCreateStep(g)
+}
+
+// onCreateBack returns to the previous step, or closes the form on the first.
+func (a *App) onCreateBack(g *gocui.Gui, v *gocui.View) error {
+	f := a.create
+	if f == nil {
+		return nil
+	}
+	switch f.step {
+	case createStepBase:
+		return a.closeCreate(g)
+	case createStepName:
+		f.name = createInput(v)
+	case createStepSystem:
+		f.system = createInput(v)
+	}
+	f.step--
+	return a.showCreateStep(g)
+}
+
+// onCreateUp moves the base model cursor up on the first step.
+func (a *App) onCreateUp(_ *gocui.Gui, v *gocui.View) error {
+	if a.create == nil || a.create.step != createStepBase {
+		return nil
+	}
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+	if cy+oy > 0 {
+		return showRow(v, cy+oy-1)
+	}
+	return nil
+}
+
+// onCreateDown moves the base model cursor down on the first step.
+func (a *App) onCreateDown(_ *gocui.Gui, v *gocui.View) error {
+	if a.create == nil || a.create.step != createStepBase {
+		return nil
+	}
+	_, cy := v.Cursor()
+	_, oy := v.Origin()
+	if cy+oy < len(a.order)-1 {
+		return showRow(v, cy+oy+1)
+	}
+	return nil
+}
+
+// closeCreate closes the form and returns focus to the installed pane.
+func (a *App) closeCreate(g *gocui.Gui) error {
+	a.create = nil
+	if err := g.DeleteView(viewCreate); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	_, err := g.SetCurrentView(viewInstalled)
+	return err
+}
+
+// runCreate creates a model in a background goroutine, reporting each new
+// progress status in the status pane and refreshing once done.
+func (a *App) runCreate(r ollama.CreateRequest) {
+	a.logf("Creating %s...", r.Model)
+	ctx, finish := a.startTask("create " + r.Model)
+	client := a.client
+	go func() {
+		last := ""
+		err := client.CreateModel(ctx, r, func(p ollama.ProgressResponse) {
+			if p.Status == last {
+				return
+			}
+			last = p.Status
+			a.safeUpdate(func(g *gocui.Gui) error {
+				a.logf("Create %s: %s", r.Model, p.Status)
+				return nil
+			})
+		})
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if isCanceled(err) {
+				a.logf("Canceled create %s", r.Model)
+				return nil
+			}
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Create "+r.Model, err)
+				a.runHook(eventError, hookEnv{Model: r.Model, Error: err.Error()})
+				return nil
+			}
+			a.logf("Created %s", r.Model)
+			a.refreshAll()
+			return nil
+		})
+	}()
+}
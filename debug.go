@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// debugModelCount is the number of fake models injected for large-list testing.
+const debugModelCount = 500
+
+// debugBindings returns the keys that inject fake data into the UI. They are
+// only registered with --debug, so contributors can exercise error, empty
+// and large-list states without a live server.
+func (a *App) debugBindings() []binding {
+	return []binding{
+		{"", gocui.KeyF5, gocui.ModNone, a.onDebugError, "debug: fake refresh error"},
+		{"", gocui.KeyF6, gocui.ModNone, a.onDebugEmpty, "debug: fake empty lists"},
+		{"", gocui.KeyF7, gocui.ModNone, a.onDebugLarge, "debug: fake large list"},
+	}
+}
+
+// injectRefresh applies a fake refresh result as if it came from the server.
+func (a *App) injectRefresh(installed, running []ollama.Model, installedErr, runningErr error) {
+	a.loaded = true
+	a.installedErr, a.runningErr = installedErr, runningErr
+	if installedErr == nil {
+		a.setInstalled(installed)
+	}
+	if runningErr == nil {
+		a.setRunning(running)
+	}
+	a.drawInstalled()
+	a.drawRunning()
+}
+
+// onDebugError makes both panes show a failed refresh.
+func (a *App) onDebugError(_ *gocui.Gui, _ *gocui.View) error {
+	err := errors.New("debug: injected failure")
+	a.injectRefresh(nil, nil, err, err)
+	a.logErr("Debug", err)
+	return nil
+}
+
+// onDebugEmpty makes both panes show a server without any models.
+func (a *App) onDebugEmpty(_ *gocui.Gui, _ *gocui.View) error {
+	a.injectRefresh(nil, nil, nil, nil)
+	a.logf("Debug: injected empty model lists")
+	return nil
+}
+
+// onDebugLarge fills the installed pane with many fake models, a few of
+// them running, to exercise scrolling and rendering of long lists.
+func (a *App) onDebugLarge(_ *gocui.Gui, _ *gocui.View) error {
+	families := []string{"llama", "qwen2", "gemma", "mistral", "phi3"}
+	now := time.Now()
+	installed := make([]ollama.Model, debugModelCount)
+	for i := range installed {
+		family := families[i%len(families)]
+		installed[i] = ollama.Model{
+			Name:     fmt.Sprintf("%s-debug-%03d:%db", family, i, 1+i%70),
+			Digest:   fmt.Sprintf("%064x", i),
+			Size:     int64(i+1) * 37 << 20,
+			Modified: now.Add(-time.Duration(i) * 7 * time.Hour),
+			Details:  ollama.ModelDetails{Family: family},
+		}
+	}
+	var running []ollama.Model
+	for i := 0; i < len(installed); i += 97 {
+		m := installed[i]
+		m.SizeVRAM = m.Size / 2
+		m.ExpiresAt = now.Add(time.Duration(i) * time.Second)
+		running = append(running, m)
+	}
+	a.injectRefresh(installed, running, nil, nil)
+	a.logf("Debug: injected %d models", len(installed))
+	return nil
+}
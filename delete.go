@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// onDelete asks for confirmation and then deletes the marked models, or the
+// selected installed model if none is marked.
+func (a *App) onDelete(g *gocui.Gui, _ *gocui.View) error {
+	if a.confirmBatch(g, "Delete", a.deleteModel) {
+		return nil
+	}
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	name := m.Name
+	msg := fmt.Sprintf("Delete %s?", displayName(name))
+	if a.isRunning(name) {
+		msg = fmt.Sprintf("Delete %s? It is currently loaded.", displayName(name))
+	}
+	a.askConfirm(g, msg, func(*gocui.Gui) error {
+		a.deleteModel(name)
+		return nil
+	})
+	return nil
+}
+
+// deleteModel deletes the named model in a background goroutine and
+// refreshes the model lists once done.
+func (a *App) deleteModel(name string) {
+	a.logf("Deleting %s...", name)
+	ctx, finish := a.startTask("delete " + name)
+	client, size := a.client, a.installedSize(name)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		err := client.DeleteModel(ctx, name)
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if isCanceled(err) {
+				a.logf("Canceled delete %s", name)
+				return nil
+			}
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Delete "+name, err)
+				a.runHook(eventError, hookEnv{Model: name, Error: err.Error()})
+				return nil
+			}
+			metricDeletes.Add(1)
+			delete(a.updates, name)
+			a.logf("Deleted %s", name)
+			a.runHook(eventDelete, hookEnv{Model: name, Size: size})
+			a.refreshAll()
+			return nil
+		})
+	}()
+}
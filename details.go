@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// layoutDetails draws the centered details overlay while a model's details
+// are open, and removes it once they have been closed.
+func (a *App) layoutDetails(g *gocui.Gui) error {
+	if a.details == nil {
+		if _, err := g.View(viewDetails); err == nil {
+			return g.DeleteView(viewDetails)
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	x0, y0 := maxX/8, maxY/8
+	x1, y1 := maxX-x0-1, maxY-y0-1
+	v, err := a.setView(g, viewDetails, x0, y0, x1, y1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = fmt.Sprintf("Details: %s (↑↓ scroll, Esc close, m copy Modelfile)", a.detailsName)
+		v.Wrap = true
+		a.drawDetails(v)
+		if _, err := g.SetCurrentView(viewDetails); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetViewOnTop(viewDetails)
+	return err
+}
+
+// drawDetails renders the currently open model details into v: a summary of
+// the model's family, size, quantization, context length and digest, its
+// parameters, system prompt, template and license, and the Modelfile. For a
+// local server the on-disk manifest and blob paths are listed as well.
+func (a *App) drawDetails(v *gocui.View) {
+	v.Clear()
+	d := a.details
+	params := d.Details.ParameterSize
+	if n := d.ParameterCount(); n > 0 {
+		params = fmt.Sprintf("%s (%d)", orNone(params), n)
+	}
+	window := "-"
+	if n := d.ContextLength(); n > 0 {
+		window = fmt.Sprintf("%d tokens", n)
+	}
+	fmt.Fprintf(v, "Family:       %s\n", orNone(d.Details.Family))
+	fmt.Fprintf(v, "Parameters:   %s\n", orNone(params))
+	fmt.Fprintf(v, "Quantization: %s\n", orNone(d.Details.QuantizationLevel))
+	fmt.Fprintf(v, "Format:       %s\n", orNone(d.Details.Format))
+	fmt.Fprintf(v, "Context:      %s\n", window)
+	fmt.Fprintf(v, "Digest:       %s\n", orNone(a.detailsDigest))
+	if a.client.IsLocal() {
+		fmt.Fprintf(v, "Path:         %s\n", ollama.ManifestPath(a.modelsDir, a.detailsName))
+	}
+	a.drawDetailsSection(v, "Parameters", d.Parameters)
+	a.drawDetailsSection(v, "System prompt", d.System)
+	a.drawDetailsSection(v, "Template", d.Template)
+	a.drawDetailsSection(v, "Modelfile", d.Modelfile)
+	a.drawDetailsSection(v, "License", d.License)
+	if a.client.IsLocal() {
+		fmt.Fprintln(v)
+		fmt.Fprintln(v, a.theme.paint(a.theme.accent, "Blobs:"))
+		if a.detailsBlobsErr != nil {
+			fmt.Fprintf(v, "  (unavailable: %v)\n", a.detailsBlobsErr)
+		}
+		for _, p := range a.detailsBlobs {
+			fmt.Fprintln(v, "  "+p)
+		}
+	}
+}
+
+// drawDetailsSection writes a titled block of text, noting when it is empty.
+func (a *App) drawDetailsSection(v *gocui.View, title, text string) {
+	fmt.Fprintln(v)
+	fmt.Fprintln(v, a.theme.paint(a.theme.accent, title+":"))
+	text = strings.TrimRight(text, "\n")
+	if strings.TrimSpace(text) == "" {
+		fmt.Fprintln(v, "  (none)")
+		return
+	}
+	fmt.Fprintln(v, text)
+}
+
+// orNone returns s, or "-" if it is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// onShowDetails fetches details for the selected installed model in a
+// background goroutine and opens the details overlay once they arrive.
+func (a *App) onShowDetails(_ *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	name, digest := m.Name, m.Digest
+	a.logf("Loading details for %s...", name)
+	client := a.client
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		info, err := client.ShowModel(ctx, name)
+		var blobs []string
+		var blobsErr error
+		if err == nil && client.IsLocal() {
+			blobs, blobsErr = ollama.BlobPaths(a.modelsDir, name)
+		}
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if err != nil {
+				a.logErr("Details "+name, err)
+				return nil
+			}
+			a.details = info
+			a.detailsName = name
+			a.detailsDigest = digest
+			a.detailsBlobs = blobs
+			a.detailsBlobsErr = blobsErr
+			return nil
+		})
+	}()
+	return nil
+}
+
+// onCloseDetails closes the details overlay and returns focus to the installed pane.
+func (a *App) onCloseDetails(g *gocui.Gui, _ *gocui.View) error {
+	a.details = nil
+	a.detailsName = ""
+	a.detailsDigest = ""
+	if err := g.DeleteView(viewDetails); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	_, err := g.SetCurrentView(viewInstalled)
+	return err
+}
+
+// onCopyModelfile copies the Modelfile of the model shown in the details
+// overlay to the clipboard.
+func (a *App) onCopyModelfile(_ *gocui.Gui, _ *gocui.View) error {
+	if a.details == nil {
+		return nil
+	}
+	if strings.TrimSpace(a.details.Modelfile) == "" {
+		a.logf("%s has no Modelfile to copy", a.detailsName)
+		return nil
+	}
+	if err := copyToClipboard(a.details.Modelfile); err != nil {
+		a.logErr("Copy Modelfile", err)
+		return nil
+	}
+	a.logf("Copied Modelfile of %s to clipboard", a.detailsName)
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// onEmbed prompts for text and embeds it with the selected installed model.
+// Several texts separated by "|" are embedded together and compared.
+func (a *App) onEmbed(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	name := m.Name
+	a.askInput(g, "Embed with "+displayName(name)+" (separate texts with |)", "", func(g *gocui.Gui, text string) error {
+		if text == "" {
+			return nil
+		}
+		var input []string
+		for _, s := range strings.Split(text, "|") {
+			if s = strings.TrimSpace(s); s != "" {
+				input = append(input, s)
+			}
+		}
+		a.embed(name, input)
+		return nil
+	})
+	return nil
+}
+
+// embed computes embeddings in a background goroutine and shows a summary in
+// the info overlay.
+func (a *App) embed(name string, input []string) {
+	a.logf("Embedding %d texts with %s...", len(input), name)
+	client := a.client
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		vecs, err := client.Embed(ctx, name, input)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Embed "+name, err)
+				return nil
+			}
+			a.logf("Embedded %d texts with %s", len(input), name)
+			return a.showInfo(g, "Embeddings: "+displayName(name), formatEmbeddings(input, vecs))
+		})
+	}()
+}
+
+// formatEmbeddings summarizes embedding vectors: their dimension, leading
+// values and, for several inputs, the pairwise cosine similarities.
+func formatEmbeddings(input []string, vecs [][]float32) string {
+	var b strings.Builder
+	for i, v := range vecs {
+		fmt.Fprintf(&b, "[%d] %q\n    %d dimensions:", i+1, input[i], len(v))
+		for j := 0; j < len(v) && j < 6; j++ {
+			fmt.Fprintf(&b, " %.4f", v[j])
+		}
+		if len(v) > 6 {
+			b.WriteString(" …")
+		}
+		b.WriteString("\n")
+	}
+	if len(vecs) > 1 {
+		b.WriteString("\nCosine similarity:\n")
+		for i := range vecs {
+			for j := i + 1; j < len(vecs); j++ {
+				fmt.Fprintf(&b, "  [%d]↔[%d]  %.4f\n", i+1, j+1, ollama.CosineSimilarity(vecs[i], vecs[j]))
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// errorRecord is the most recent error reported in the status pane.
+type errorRecord struct {
+	what string    // What was being done, e.g. "Load llama3"
+	err  error     // The error itself, possibly wrapping others
+	at   time.Time // When the error occurred
+}
+
+// logErr logs err to the status pane prefixed with what, and remembers it as
+// the last error so its full text can be inspected in the error overlay.
+func (a *App) logErr(what string, err error) {
+	a.lastErr = &errorRecord{what: what, err: err, at: time.Now()}
+	if hint := a.errorHint(err); hint != "" {
+		a.logf("%s: %s", what, hint)
+		return
+	}
+	a.logf("%s: %v", what, err)
+}
+
+// errorHint returns a short explanation for well-known client errors, or ""
+// if err should be shown as is. The full error remains in the error overlay.
+func (a *App) errorHint(err error) string {
+	switch {
+	case errors.Is(err, ollama.ErrServerUnreachable):
+		return fmt.Sprintf("cannot reach Ollama at %s — is it running?", a.baseURL)
+	case errors.Is(err, ollama.ErrUnauthorized):
+		return "not authorized by the server (press E for details)"
+	case errors.Is(err, ollama.ErrModelNotFound):
+		return "model not found (press E for details)"
+	}
+	return ""
+}
+
+// formatErrorRecord renders an error and its wrapped chain for the error overlay.
+func formatErrorRecord(r *errorRecord) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n\n", r.what, r.at.Format(time.RFC3339))
+	fmt.Fprintf(&b, "%v\n", r.err)
+	if errors.Unwrap(r.err) == nil {
+		return b.String()
+	}
+	b.WriteString("\nError chain:\n")
+	for i, e := 0, r.err; e != nil; i, e = i+1, errors.Unwrap(e) {
+		fmt.Fprintf(&b, "%2d. (%T) %v\n", i+1, e, e)
+	}
+	return b.String()
+}
+
+// onShowLastError opens an overlay with the full text of the most recent error.
+func (a *App) onShowLastError(g *gocui.Gui, _ *gocui.View) error {
+	if a.lastErr == nil {
+		a.logf("No errors so far")
+		return nil
+	}
+	return a.showInfo(g, "Last error", formatErrorRecord(a.lastErr))
+}
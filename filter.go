@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jroimartin/gocui"
+)
+
+// fuzzyMatch reports whether the characters of pattern appear in s in order,
+// ignoring case, so that "l3q4" matches "llama3:8b-q4_K_M".
+func fuzzyMatch(pattern, s string) bool {
+	s = strings.ToLower(s)
+	for _, r := range strings.ToLower(pattern) {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+utf8.RuneLen(r):]
+	}
+	return true
+}
+
+// onStartFilter opens the filter input over the bottom of the installed
+// pane, starting from the current filter. The list narrows as you type.
+func (a *App) onStartFilter(g *gocui.Gui, _ *gocui.View) error {
+	a.filterInput.Ask(g, "Filter (Enter keep, Ctrl+P history, Esc clear)", a.filter, viewInstalled, func(*gocui.Gui, string) error {
+		return nil
+	})
+	a.filterInput.OnChange(func(text string) {
+		a.filter = text
+		a.reorderInstalled(g)
+	})
+	return nil
+}
+
+// layoutFilter draws the filter input, while it is open, over the last rows
+// of the installed pane.
+func (a *App) layoutFilter(g *gocui.Gui) error {
+	x0, _, x1, y1, err := g.ViewPosition(viewInstalled)
+	if err != nil {
+		return err
+	}
+	return a.filterInput.Layout(g, x0, y1-2, x1)
+}
+
+// onClearFilter closes the filter input, if open, and shows all models
+// again.
+func (a *App) onClearFilter(g *gocui.Gui, v *gocui.View) error {
+	if !a.filterInput.Open() && a.filter == "" {
+		return nil
+	}
+	if err := a.filterInput.Cancel(g, v); err != nil {
+		return err
+	}
+	a.filter = ""
+	return a.reorderInstalled(g)
+}
@@ -2,10 +2,12 @@ module olazyllama
 
 go 1.25.1
 
-require github.com/jroimartin/gocui v0.5.0
+require (
+	github.com/jroimartin/gocui v0.5.0
+	github.com/mattn/go-runewidth v0.0.16
+)
 
 require (
-	github.com/mattn/go-runewidth v0.0.16 // indirect
 	github.com/nsf/termbox-go v1.1.1 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 )
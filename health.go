@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// healthInterval is how often the server is pinged to detect lost and
+// restored connections between refreshes.
+const healthInterval = 10 * time.Second
+
+// monitorHealth pings the server's version endpoint every healthInterval
+// for the lifetime of the program, following host switches.
+func (a *App) monitorHealth() {
+	go func() {
+		ticker := time.NewTicker(healthInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			client, _, gen := a.conn()
+			version, err := client.Version(ctx)
+			cancel()
+			a.safeUpdate(func(g *gocui.Gui) error {
+				if gen != a.hostGen {
+					return nil
+				}
+				a.recordHealth(g, version, err)
+				return nil
+			})
+		}
+	}()
+}
+
+// recordHealth updates the connection state from a health check. Losing the
+// connection is logged once; when the server answers again the model lists
+// are refreshed, which replaces the stale pane errors and logs the reconnect.
+func (a *App) recordHealth(g *gocui.Gui, version string, err error) {
+	if err != nil {
+		if isTransient(err) && !a.offline {
+			a.offline = true
+			a.logf("Lost connection to %s", a.baseURL)
+		}
+		a.versionErr = err
+		a.updateStatusTitle(g)
+		return
+	}
+	a.versionErr = nil
+	a.serverVersion = version
+	a.updateStatusTitle(g)
+	if a.offline {
+		a.refreshAll()
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// helpScopes names the binding scopes in the help overlay.
+var helpScopes = map[string]string{
+	"":               "Everywhere",
+	viewInstalled:    "Installed models",
+	viewRunning:      "Running models",
+	viewStatus:       "Status",
+	viewTasks:        "Tasks",
+	viewFilter:       "Filter input",
+	viewDetails:      "Model details",
+	viewLibrary:      "Library browser",
+	viewChatInput:    "Chat playground",
+	viewLogs:         "Server log",
+	viewStatusLog:    "Status log",
+	viewInfo:         "Text overlays",
+	viewCreate:       "Create form",
+	viewPrompt:       "Text prompt",
+	viewPaletteInput: "Command palette",
+	viewConfirm:      "Confirmation",
+}
+
+// helpText lists every key binding in table grouped by scope, in table
+// order. Keys sharing a description within a scope are listed together.
+func helpText(table []binding) string {
+	var scopes []string
+	descs := make(map[string][]string)
+	keys := make(map[string][]string)
+	for _, b := range table {
+		if _, ok := descs[b.view]; !ok {
+			scopes = append(scopes, b.view)
+		}
+		id := b.view + "\x00" + b.desc
+		if _, ok := keys[id]; !ok {
+			descs[b.view] = append(descs[b.view], b.desc)
+		}
+		keys[id] = append(keys[id], keyName(b.key))
+	}
+	var sb strings.Builder
+	for i, scope := range scopes {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		title, ok := helpScopes[scope]
+		if !ok {
+			title = scope
+		}
+		sb.WriteString(title + ":\n")
+		for _, d := range descs[scope] {
+			fmt.Fprintf(&sb, "  %-22s %s\n", strings.Join(keys[scope+"\x00"+d], " "), d)
+		}
+	}
+	return sb.String()
+}
+
+// onShowHelp opens an overlay listing all key bindings.
+func (a *App) onShowHelp(g *gocui.Gui, _ *gocui.View) error {
+	return a.showInfo(g, "Keys", helpText(a.keys))
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// Events that can trigger hook commands configured under "hooks".
+const (
+	eventPullComplete = "pull-complete" // A model finished pulling
+	eventDelete       = "delete"        // A model was deleted
+	eventError        = "error"         // An operation or refresh failed
+)
+
+// hookEvents lists all valid hook event names.
+var hookEvents = []string{eventPullComplete, eventDelete, eventError}
+
+// hookTimeout bounds how long a single hook command may run.
+const hookTimeout = 30 * time.Second
+
+// hookEnv describes an event for a hook command. Fields are exposed to the
+// command as OLAZYLLAMA_* environment variables.
+type hookEnv struct {
+	Model string // Model the event concerns, if any
+	Size  int64  // Size of the model in bytes, if known
+	Error string // Error message for error events
+}
+
+// shellCommand returns a command running line through the platform shell.
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", line)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", line)
+}
+
+// runHook runs the command configured for event, if any, in a background
+// goroutine so the UI is never blocked. Failures are reported in the status pane.
+func (a *App) runHook(event string, env hookEnv) {
+	line := a.config.Hooks[event]
+	if line == "" {
+		return
+	}
+	host := a.baseURL
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+		defer cancel()
+
+		cmd := shellCommand(ctx, line)
+		cmd.Env = append(os.Environ(),
+			"OLAZYLLAMA_EVENT="+event,
+			"OLAZYLLAMA_MODEL="+env.Model,
+			"OLAZYLLAMA_SIZE="+strconv.FormatInt(env.Size, 10),
+			"OLAZYLLAMA_ERROR="+env.Error,
+			"OLAZYLLAMA_HOST="+host,
+		)
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			return
+		}
+		msg := strings.TrimSpace(string(out))
+		if i := strings.IndexByte(msg, '\n'); i >= 0 {
+			msg = msg[:i]
+		}
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			a.logErr("Hook "+event+" failed", err)
+			return nil
+		})
+	}()
+}
+
+// validateHooks checks that every configured hook names a known event.
+func validateHooks(hooks map[string]string) error {
+	for event := range hooks {
+		known := false
+		for _, e := range hookEvents {
+			if e == event {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("hooks: unknown event %q (want one of %s)", event, strings.Join(hookEvents, ", "))
+		}
+	}
+	return nil
+}
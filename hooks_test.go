@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestRunHookEnv checks that a hook command sees the event, model and size
+// in its environment.
+func TestRunHookEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook command uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "hook")
+	a := newApp("http://localhost:11434")
+	a.config.Hooks = map[string]string{
+		eventDelete: `echo "$OLAZYLLAMA_EVENT $OLAZYLLAMA_MODEL $OLAZYLLAMA_SIZE $OLAZYLLAMA_HOST" > ` + out + `.tmp && mv ` + out + `.tmp ` + out,
+	}
+	a.runHook(eventDelete, hookEnv{Model: "llama3.2:latest", Size: 2019393189})
+
+	want := "delete llama3.2:latest 2019393189 http://localhost:11434\n"
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if got, err := os.ReadFile(out); err == nil {
+			if string(got) != want {
+				t.Errorf("hook saw %q, want %q", got, want)
+			}
+			return
+		}
+	}
+	t.Fatal("hook did not run")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// onSwitchHost asks for a server address and connects to it.
+func (a *App) onSwitchHost(g *gocui.Gui, _ *gocui.View) error {
+	a.askInput(g, "Switch to Ollama server", a.baseURL, func(g *gocui.Gui, host string) error {
+		if host == "" {
+			return nil
+		}
+		a.switchHost(g, host)
+		return nil
+	})
+	return nil
+}
+
+// switchHost connects to host and reloads everything from the new server.
+// host may be given in any form OLLAMA_HOST accepts, or as a unix:// socket
+// URL. Operations already running continue against the previous server, but
+// refreshes started before the switch are dropped when they complete.
+func (a *App) switchHost(g *gocui.Gui, host string) {
+	if err := a.connect(host); err != nil {
+		a.logf("Warning: %v", err)
+	}
+	a.logf("Switched to %s", a.baseURL)
+	a.updateStatusTitle(g)
+	a.drawInstalled()
+	a.checkVersion()
+	a.refreshAll()
+}
+
+// connect replaces the client with one for host, configured and traced like
+// the current one, and resets the state that belonged to the previous
+// server. The returned error reports configuration that could not be
+// applied; the client is replaced regardless.
+func (a *App) connect(host string) error {
+	c := ollama.NewClient(parseOllamaHost(host))
+	err := a.config.configureClient(c)
+	if a.trace != nil {
+		c.EnableTrace(a.trace, a.traceBodies)
+	}
+	var client ollama.API = c
+	if a.backend == BackendOpenAI {
+		client = ollama.NewOpenAIClient(c)
+	}
+	a.connMu.Lock()
+	a.client, a.baseURL = client, c.BaseURL
+	a.hostGen++
+	a.connMu.Unlock()
+	a.serverVersion, a.versionErr, a.offline = "", nil, false
+	a.runningDetailed = !a.config.CompactRunning
+	a.updates = make(map[string]updateState)
+	a.marked = make(map[string]bool)
+	a.loaded = false
+	return err
+}
+
+// conn returns the current client, server URL and host generation. Only
+// goroutines other than the GUI goroutine need it; code running on the GUI
+// goroutine may read the fields directly, since only it replaces them.
+func (a *App) conn() (ollama.API, string, int) {
+	a.connMu.RLock()
+	defer a.connMu.RUnlock()
+	return a.client, a.baseURL, a.hostGen
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"olazyllama/internal/ollama"
+)
+
+// TestSwitchHostDropsStaleRefresh checks that a refresh fetched from the
+// previous server before a host switch does not overwrite the new server's
+// panes when it completes.
+func TestSwitchHostDropsStaleRefresh(t *testing.T) {
+	a := newApp("http://old.example:11434")
+	old := &ollama.MockClient{
+		Installed: models("llama3.2:latest", "qwen2.5:7b"),
+		Running:   models("llama3.2:latest"),
+	}
+	a.client = old
+	gen := a.hostGen
+	installed, running, err1, err2 := fetchWithRetry(context.Background(), old)
+
+	if err := a.connect("new.example:11434"); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if a.baseURL != "http://new.example:11434" {
+		t.Errorf("baseURL = %q after switching, want http://new.example:11434", a.baseURL)
+	}
+	a.applyRefresh(refreshResult{gen: gen, installed: installed, running: running, installedErr: err1, runningErr: err2})
+	if a.loaded || len(a.installed) != 0 || len(a.running) != 0 {
+		t.Errorf("loaded=%v with %d installed and %d running models, want the stale refresh dropped",
+			a.loaded, len(a.installed), len(a.running))
+	}
+
+	a.applyRefresh(refreshResult{gen: a.hostGen, installed: installed, running: running, installedErr: err1, runningErr: err2})
+	if !a.loaded || len(a.installed) != 2 {
+		t.Errorf("loaded=%v with %d installed models, want a current refresh applied", a.loaded, len(a.installed))
+	}
+}
+
+// TestSwitchHostKeepsTrace checks that the client created for a new host
+// writes to the --debug trace like the one it replaces.
+func TestSwitchHostKeepsTrace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version":"0.6.0"}`))
+	}))
+	defer srv.Close()
+
+	var trace bytes.Buffer
+	a := newApp("")
+	a.trace = &trace
+	if err := a.connect(srv.URL); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if _, err := a.client.Version(context.Background()); err != nil {
+		t.Fatalf("Version: %v", err)
+	}
+	if !bytes.Contains(trace.Bytes(), []byte("/api/version")) {
+		t.Errorf("trace = %q, want the version request", trace.String())
+	}
+}
+
+// TestConnConcurrentWithSwitch checks, under -race, that background
+// goroutines can read the connection while the host is switched.
+func TestConnConcurrentWithSwitch(t *testing.T) {
+	a := newApp("http://localhost:11434")
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				if client, baseURL, _ := a.conn(); client == nil || baseURL == "" {
+					t.Error("conn returned an empty connection")
+					return
+				}
+			}
+		}
+	}()
+	for _, host := range []string{"a.example", "b.example:8080", "unix:///tmp/ollama.sock"} {
+		a.connect(host)
+	}
+	close(done)
+	wg.Wait()
+	if _, _, gen := a.conn(); gen != 3 {
+		t.Errorf("hostGen = %d after three switches, want 3", gen)
+	}
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// sameModels reports whether two model lists hold the same models in the same
+// order, so an unchanged refresh can keep the cached display order.
+func sameModels(a, b []ollama.Model) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Digest != b[i].Digest || a[i].Size != b[i].Size {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuildOrder recomputes the display order of the installed models. In
+// grouped mode models are ordered by family, with the "other" group last,
+// and by the sort mode (name by default) within each family; otherwise they
+// follow the sort mode, or the server's order without one. The
+// running-first modifier then floats loaded models to the top (of each
+// group), preserving the order among the rest. Models not matching the
+// filter are left out. The selection stays on the same model.
+func (a *App) rebuildOrder() {
+	a.reorder(a.selectedName())
+}
+
+// setInstalled replaces the installed model list and rebuilds the display
+// order, keeping the selection on the same model if it is still installed.
+// The selected name must be read before the list is replaced, since the
+// current order indexes the old list.
+func (a *App) setInstalled(models []ollama.Model) {
+	name := a.selectedName()
+	a.installed = models
+	a.reorder(name)
+}
+
+// selectedName returns the name of the selected installed model, or "" if
+// the list is empty.
+func (a *App) selectedName() string {
+	if m := a.selectedModel(); m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// reorder recomputes the display order as described for rebuildOrder and
+// then selects the model named selectedName.
+func (a *App) reorder(selectedName string) {
+	a.order = a.order[:0]
+	a.installedNames = make(map[string]bool, len(a.installed))
+	for i, m := range a.installed {
+		a.installedNames[ollama.NormalizeName(m.Name)] = true
+		if fuzzyMatch(a.filter, m.Name) {
+			a.order = append(a.order, i)
+		}
+	}
+	firstRunning := func(mi, mj ollama.Model) (less, decided bool) {
+		if !a.runningFirst {
+			return false, false
+		}
+		ri, rj := a.isRunning(mi.Name), a.isRunning(mj.Name)
+		return ri && !rj, ri != rj
+	}
+	sort.SliceStable(a.order, func(i, j int) bool {
+		mi, mj := a.installed[a.order[i]], a.installed[a.order[j]]
+		if !a.groupByFamily {
+			if less, ok := firstRunning(mi, mj); ok {
+				return less
+			}
+			return a.sortMode.less(mi, mj)
+		}
+		fi, fj := mi.Family(), mj.Family()
+		if fi != fj {
+			if fi == ollama.FamilyOther || fj == ollama.FamilyOther {
+				return fj == ollama.FamilyOther
+			}
+			return fi < fj
+		}
+		if less, ok := firstRunning(mi, mj); ok {
+			return less
+		}
+		if a.sortMode != sortNone {
+			return a.sortMode.less(mi, mj)
+		}
+		return mi.Name < mj.Name
+	})
+	a.selectName(selectedName)
+}
+
+// isInstalled reports whether a model with the given name is installed,
+// comparing normalized names.
+func (a *App) isInstalled(name string) bool {
+	return a.installedNames[ollama.NormalizeName(name)]
+}
+
+// installedSize returns the size of the installed model with the given
+// name, comparing normalized names, or 0 if it is not installed.
+func (a *App) installedSize(name string) int64 {
+	name = ollama.NormalizeName(name)
+	for _, m := range a.installed {
+		if ollama.NormalizeName(m.Name) == name {
+			return m.Size
+		}
+	}
+	return 0
+}
+
+// isRunning reports whether a model with the given name is loaded,
+// comparing normalized names.
+func (a *App) isRunning(name string) bool {
+	return a.runningNames[ollama.NormalizeName(name)]
+}
+
+// setRunning replaces the running model list, reordering the installed list
+// if the running-first modifier depends on it.
+func (a *App) setRunning(running []ollama.Model) {
+	changed := !sameModels(a.running, running)
+	a.running = running
+	a.runningNames = make(map[string]bool, len(running))
+	for _, m := range running {
+		a.runningNames[ollama.NormalizeName(m.Name)] = true
+	}
+	if changed && a.runningFirst {
+		a.rebuildOrder()
+	}
+}
+
+// installedTitle returns the installed pane title, naming active ordering
+// modes and the filter.
+func (a *App) installedTitle() string {
+	var modes []string
+	if len(a.marked) > 0 {
+		modes = append(modes, fmt.Sprintf("%d marked", len(a.marked)))
+	}
+	if a.filter != "" {
+		modes = append(modes, fmt.Sprintf("/%s %d of %d", a.filter, len(a.order), len(a.installed)))
+	}
+	if a.sortMode != sortNone {
+		modes = append(modes, "sorted by "+a.sortMode.String())
+	}
+	if a.groupByFamily {
+		modes = append(modes, "by family")
+	}
+	if a.runningFirst {
+		modes = append(modes, "running first")
+	}
+	title := "Installed Models"
+	if len(modes) > 0 {
+		title += " [" + strings.Join(modes, ", ") + "]"
+	}
+	return title + loadingSuffix(a.loadingInstalled.Load())
+}
+
+// selectName moves the selection to the model with the given name, keeping
+// the current position (clamped) if it is no longer in the list.
+func (a *App) selectName(name string) {
+	if name != "" {
+		for i, idx := range a.order {
+			if a.installed[idx].Name == name {
+				a.selected = i
+				return
+			}
+		}
+	}
+	a.clampSelection()
+}
+
+// installedLine formats a single installed model row: a mark while any
+// model is marked, the name, parameter size and quantization, followed by
+// the size unless it is hidden.
+func (a *App) installedLine(m ollama.Model) string {
+	var line string
+	switch {
+	case a.marked[m.Name]:
+		line = a.theme.paint(a.theme.accent, "✓ ")
+	case len(a.marked) > 0:
+		line = "  "
+	}
+	line += fitWidth(displayName(m.Name), 32) + "  " +
+		fitWidth(orNone(m.Details.ParameterSize), 6) + "  " +
+		fitWidth(orNone(m.Details.QuantizationLevel), 8)
+	if m.Size > 0 && !a.config.HideSize {
+		line += "  " + a.theme.paint(a.theme.accent, fmt.Sprintf("%10s", ollama.HumanSize(m.Size)))
+	}
+	if a.showAge {
+		if label := a.ageLabel(m.Modified, time.Now()); label != "" {
+			line += "  " + label
+		}
+	}
+	if badge := a.updateBadge(m.Name); badge != "" {
+		line += "  " + badge
+	}
+	return line
+}
+
+// drawInstalled updates the installed models view with the current list.
+// Shows model names and sizes in a formatted display, optionally under
+// family headers. Only the rows that fit in the pane are rendered.
+func (a *App) drawInstalled() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewInstalled)
+		if err != nil {
+			return nil
+		}
+		v.Clear()
+		if !a.loaded {
+			v.Highlight = false
+			fmt.Fprintln(v, "Loading models...")
+			return nil
+		}
+		if a.installedErr != nil {
+			a.drawPaneError(v, "installed models", a.installedErr)
+			return nil
+		}
+		if len(a.order) == 0 && a.filter != "" {
+			v.Highlight = false
+			fmt.Fprintf(v, "(no models match %q — Esc clears the filter)\n", a.filter)
+			return nil
+		}
+		if len(a.order) == 0 {
+			v.Highlight = false
+			fmt.Fprintln(v, "(no models installed)")
+			return nil
+		}
+		a.clampSelection()
+		// Lay out the rows first, which is cheap, and format only the
+		// visible ones.
+		a.installedRows = a.installedRows[:0]
+		cursor, family := 0, ""
+		for i, idx := range a.order {
+			m := a.installed[idx]
+			if a.groupByFamily && (i == 0 || m.Family() != family) {
+				family = m.Family()
+				a.installedRows = append(a.installedRows, -1)
+			}
+			if i == a.selected {
+				cursor = len(a.installedRows)
+			}
+			a.installedRows = append(a.installedRows, i)
+		}
+		v.Highlight = true
+		return a.installedWin.draw(v, &a.drawBuf, len(a.installedRows), cursor, func(buf *bytes.Buffer, row int) {
+			i := a.installedRows[row]
+			if i < 0 {
+				// Headers are always followed by a model of their family.
+				family := a.installed[a.order[a.installedRows[row+1]]].Family()
+				buf.WriteString(a.theme.paint(a.theme.accent, "── "+family+" ──"))
+				return
+			}
+			if a.groupByFamily {
+				buf.WriteString("  ")
+			}
+			buf.WriteString(a.installedLine(a.installed[a.order[i]]))
+		})
+	})
+}
+
+// showRow places the cursor on the given buffer row, scrolling the view's
+// origin as little as possible to keep that row visible.
+func showRow(v *gocui.View, row int) error {
+	_, h := v.Size()
+	if h <= 0 {
+		return nil
+	}
+	_, oy := v.Origin()
+	switch {
+	case row < oy:
+		oy = row
+	case row >= oy+h:
+		oy = row - h + 1
+	}
+	if err := v.SetOrigin(0, oy); err != nil {
+		return err
+	}
+	return v.SetCursor(0, row-oy)
+}
+
+// clampSelection keeps the selected index within the bounds of the installed list.
+func (a *App) clampSelection() {
+	if a.selected >= len(a.order) {
+		a.selected = len(a.order) - 1
+	}
+	if a.selected < 0 {
+		a.selected = 0
+	}
+}
+
+// selectedModel returns the currently selected installed model, or nil if
+// the list is empty.
+func (a *App) selectedModel() *ollama.Model {
+	if len(a.order) == 0 {
+		return nil
+	}
+	a.clampSelection()
+	return &a.installed[a.order[a.selected]]
+}
+
+// onCursorUp moves the installed selection one row up.
+func (a *App) onCursorUp(_ *gocui.Gui, _ *gocui.View) error {
+	if a.selected > 0 {
+		a.selected--
+		a.drawInstalled()
+	}
+	return nil
+}
+
+// onCursorDown moves the installed selection one row down.
+func (a *App) onCursorDown(_ *gocui.Gui, _ *gocui.View) error {
+	if a.selected < len(a.order)-1 {
+		a.selected++
+		a.drawInstalled()
+	}
+	return nil
+}
+
+// onToggleSize shows or hides the size column and saves the preference.
+func (a *App) onToggleSize(_ *gocui.Gui, _ *gocui.View) error {
+	a.config.HideSize = !a.config.HideSize
+	a.drawInstalled()
+	a.persistConfig()
+	return nil
+}
+
+// onToggleGroupByFamily switches between a flat list and models grouped by family.
+func (a *App) onToggleGroupByFamily(g *gocui.Gui, _ *gocui.View) error {
+	a.groupByFamily = !a.groupByFamily
+	return a.reorderInstalled(g)
+}
+
+// onToggleRunningFirst toggles the modifier that lists loaded models first.
+func (a *App) onToggleRunningFirst(g *gocui.Gui, _ *gocui.View) error {
+	a.runningFirst = !a.runningFirst
+	return a.reorderInstalled(g)
+}
+
+// reorderInstalled rebuilds the installed order after an ordering mode
+// changed and updates the pane title to match.
+func (a *App) reorderInstalled(g *gocui.Gui) error {
+	a.rebuildOrder()
+	if v, err := g.View(viewInstalled); err == nil {
+		v.Title = a.installedTitle()
+	}
+	a.drawInstalled()
+	return nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+
+	"olazyllama/internal/ollama"
+)
+
+// pathologicalNames are model names that have broken formatting or name
+// matching before: spaces, colons, slashes, registries with ports, wide and
+// control characters.
+var pathologicalNames = []string{
+	"my model",
+	"my model:v1 beta",
+	"user/name with spaces:tag",
+	"registry.example.com:5000/team/model",
+	"registry.example.com:5000/team/model:q4_K_M",
+	"a/b/c/d:e",
+	"模型/中文名字:latest",
+	"tab\tand\nnewline",
+	"",
+}
+
+// models returns installed models with the given names.
+func models(names ...string) []ollama.Model {
+	ms := make([]ollama.Model, len(names))
+	for i, n := range names {
+		ms[i] = ollama.Model{Name: n, Digest: fmt.Sprintf("%064d", i), Size: int64(i+1) << 30}
+	}
+	return ms
+}
+
+// TestSetInstalledKeepsSelection checks that replacing the installed list
+// keeps the selection on the same model, or clamps it when that model is
+// gone, however the list changed.
+func TestSetInstalledKeepsSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   []string
+		selected int
+		after    []string
+		want     string // Selected name afterwards, "" for none
+	}{
+		{"last model removed", []string{"a", "b", "c", "d", "e"}, 4, []string{"a", "b", "c", "d"}, "d"},
+		{"selected model moved", []string{"a", "b", "c"}, 2, []string{"c", "a"}, "c"},
+		{"model inserted before selection", []string{"b", "c"}, 1, []string{"a", "b", "c"}, "c"},
+		{"only model removed", []string{"a"}, 0, nil, ""},
+		{"list emptied", []string{"a", "b", "c"}, 1, []string{}, ""},
+		{"from empty", nil, 0, []string{"a", "b"}, "a"},
+		{"pathological names", pathologicalNames, 3, pathologicalNames[2:], "registry.example.com:5000/team/model"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newApp("")
+			a.setInstalled(models(tt.before...))
+			a.selected = tt.selected
+			a.setInstalled(models(tt.after...))
+			got := ""
+			if m := a.selectedModel(); m != nil {
+				got = m.Name
+			}
+			if got != tt.want {
+				t.Errorf("selected %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSelectionSurvivesReorder checks that toggling ordering modes keeps the
+// selection on a model with an unusual name.
+func TestSelectionSurvivesReorder(t *testing.T) {
+	a := newApp("")
+	a.setInstalled(models(pathologicalNames...))
+	want := "user/name with spaces:tag"
+	a.selectName(want)
+	for _, mode := range []sortMode{sortNameDesc, sortSizeAsc, sortNone} {
+		a.sortMode = mode
+		a.groupByFamily = !a.groupByFamily
+		a.rebuildOrder()
+		if m := a.selectedModel(); m == nil || m.Name != want {
+			t.Fatalf("sort %v: selected %v, want %q", mode, m, want)
+		}
+	}
+}
+
+// TestNameMatching checks that installed and running models are matched by
+// normalized name, including names with spaces, slashes and ports.
+func TestNameMatching(t *testing.T) {
+	a := newApp("")
+	a.setInstalled(models(pathologicalNames...))
+	a.setRunning(models("MY MODEL:latest", "registry.example.com:5000/team/model:latest", "user/name with spaces"))
+	tests := []struct {
+		name    string
+		running bool
+	}{
+		{"my model", true},
+		{"my model:v1 beta", false},
+		{"registry.example.com:5000/team/model", true},
+		{"registry.example.com:5000/team/model:q4_K_M", false},
+		{"user/name with spaces:tag", false},
+		{"a/b/c/d:e", false},
+	}
+	for _, tt := range tests {
+		if got := a.isRunning(tt.name); got != tt.running {
+			t.Errorf("isRunning(%q) = %v, want %v", tt.name, got, tt.running)
+		}
+		if !a.isInstalled(tt.name) {
+			t.Errorf("isInstalled(%q) = false", tt.name)
+		}
+	}
+	if a.isInstalled("user/name with spaces") {
+		t.Error(`isInstalled("user/name with spaces") = true, want false (only :tag is installed)`)
+	}
+}
+
+// TestInstalledLineAlignment checks that every row of the installed pane
+// is equally wide, whatever the model is called.
+func TestInstalledLineAlignment(t *testing.T) {
+	a := newApp("")
+	a.config.HideSize = true
+	want := -1
+	for _, m := range models(append(pathologicalNames, "a-very-long-model-name-that-does-not-fit-in-the-column:latest")...) {
+		line := a.installedLine(m)
+		w := runewidth.StringWidth(line)
+		if want < 0 {
+			want = w
+		}
+		if w != want {
+			t.Errorf("installedLine(%q) is %d cells wide, want %d: %q", m.Name, w, want, line)
+		}
+	}
+}
+
+// TestInstalledSize checks that sizes are looked up by normalized name, as
+// the delete and pull-complete hooks do, and are 0 for unknown models.
+func TestInstalledSize(t *testing.T) {
+	a := newApp("")
+	a.setInstalled(models("llama3.2:latest", "qwen2.5:7b"))
+	tests := []struct {
+		name string
+		want int64
+	}{
+		{"llama3.2:latest", 1 << 30},
+		{"llama3.2", 1 << 30},
+		{"qwen2.5:7b", 2 << 30},
+		{"qwen2.5", 0},
+		{"mistral", 0},
+	}
+	for _, tt := range tests {
+		if got := a.installedSize(tt.name); got != tt.want {
+			t.Errorf("installedSize(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// largeApp returns an app holding the debugModelCount fake models of the
+// --debug large-list key, a few of them running.
+func largeApp(b *testing.B) *App {
+	b.Helper()
+	a := newApp("")
+	if err := a.onDebugLarge(nil, nil); err != nil {
+		b.Fatal(err)
+	}
+	return a
+}
+
+// BenchmarkRebuildOrder measures recomputing the installed order of a large
+// list in each ordering mode.
+func BenchmarkRebuildOrder(b *testing.B) {
+	modes := []struct {
+		name                        string
+		sort                        sortMode
+		groupByFamily, runningFirst bool
+	}{
+		{"server", sortNone, false, false},
+		{"size", sortSizeDesc, false, false},
+		{"grouped", sortNone, true, false},
+		{"grouped running first", sortNameAsc, true, true},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			a := largeApp(b)
+			a.sortMode, a.groupByFamily, a.runningFirst = mode.sort, mode.groupByFamily, mode.runningFirst
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				a.rebuildOrder()
+			}
+		})
+	}
+}
+
+// BenchmarkInstalledLines measures formatting every row of a large
+// installed list, as drawInstalled does for the rows it shows.
+func BenchmarkInstalledLines(b *testing.B) {
+	a := largeApp(b)
+	a.showAge = true
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.drawBuf.Reset()
+		for _, idx := range a.order {
+			a.drawBuf.WriteString(a.installedLine(a.installed[idx]))
+			a.drawBuf.WriteByte('\n')
+		}
+	}
+}
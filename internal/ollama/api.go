@@ -0,0 +1,33 @@
+package ollama
+
+import "context"
+
+// API is the set of Ollama operations used by the application. It is
+// implemented by Client and, for tests, by MockClient.
+type API interface {
+	ListLocalModels(ctx context.Context) ([]Model, error)
+	ListRunning(ctx context.Context) ([]Model, error)
+	ShowModel(ctx context.Context, name string) (*ModelInfo, error)
+	ShowModels(ctx context.Context, names []string, concurrency int) (map[string]*ModelInfo, []error)
+	Version(ctx context.Context) (string, error)
+	IsLocal() bool
+
+	PullModel(ctx context.Context, name string, progress func(ProgressResponse)) error
+	PushModel(ctx context.Context, name string, insecure bool, progress func(ProgressResponse)) error
+	CreateModel(ctx context.Context, r CreateRequest, progress func(ProgressResponse)) error
+	CopyModel(ctx context.Context, source, destination string) error
+	DeleteModel(ctx context.Context, name string) error
+	RemoteDigest(ctx context.Context, name string) (string, error)
+
+	Preload(ctx context.Context, name, keepAlive string) error
+	Unload(ctx context.Context, name string) error
+	Generate(ctx context.Context, r GenerateRequest, fn func(GenerateResponse) error) error
+	Chat(ctx context.Context, r ChatRequest, onChunk func(ChatResponse) error) (Metrics, error)
+	Embed(ctx context.Context, model string, input []string) ([][]float32, error)
+	Tokenize(ctx context.Context, model, text string) ([]int, error)
+	Detokenize(ctx context.Context, model string, tokens []int) (string, error)
+
+	StreamLogs(ctx context.Context, fn func(line string)) error
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,64 @@
+package ollama
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ShowModels fetches details for several models concurrently, using at most
+// concurrency simultaneous requests (1 if concurrency is not positive).
+// Failures are collected per model without aborting the batch; if ctx is
+// canceled, the remaining models are skipped and reported with ctx's error.
+func (c *Client) ShowModels(ctx context.Context, names []string, concurrency int) (map[string]*ModelInfo, []error) {
+	return showModels(ctx, c, names, concurrency)
+}
+
+// showModels implements ShowModels for any API by calling its ShowModel from
+// a pool of concurrency workers.
+func showModels(ctx context.Context, api API, names []string, concurrency int) (map[string]*ModelInfo, []error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		infos = make(map[string]*ModelInfo, len(names))
+		errs  []error
+		jobs  = make(chan string)
+	)
+	for i := 0; i < concurrency && i < len(names); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				info, err := api.ShowModel(ctx, name)
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				} else {
+					infos[name] = info
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i, name := range names {
+		select {
+		case jobs <- name:
+		case <-ctx.Done():
+			mu.Lock()
+			for _, skipped := range names[i:] {
+				errs = append(errs, fmt.Errorf("%s: %w", skipped, ctx.Err()))
+			}
+			mu.Unlock()
+			close(jobs)
+			wg.Wait()
+			return infos, errs
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return infos, errs
+}
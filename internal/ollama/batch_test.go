@@ -0,0 +1,110 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// showServer returns a server answering /api/show after delay, with 404 for
+// the models in missing. It records the most requests it saw at once.
+func showServer(t *testing.T, delay time.Duration, missing map[string]bool, peak *atomic.Int32) *httptest.Server {
+	t.Helper()
+	var inFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		var req struct {
+			Model string `json:"model"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if missing[req.Model] {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"model '` + req.Model + `' not found"}`))
+			return
+		}
+		w.Write([]byte(`{"details":{"family":"` + req.Model + `"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestShowModels checks that ShowModels keeps to its concurrency limit and
+// collects per-model failures without giving up on the other models.
+func TestShowModels(t *testing.T) {
+	var peak atomic.Int32
+	srv := showServer(t, 50*time.Millisecond, map[string]bool{"b": true, "e": true}, &peak)
+	c := NewClient(srv.URL)
+
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	infos, errs := c.ShowModels(context.Background(), names, 3)
+	if got := peak.Load(); got != 3 {
+		t.Errorf("at most %d requests at once, want 3", got)
+	}
+	if len(infos) != 5 {
+		t.Errorf("got details of %d models, want 5", len(infos))
+	}
+	for _, name := range []string{"a", "c", "d", "f", "g"} {
+		if info := infos[name]; info == nil || info.Details.Family != name {
+			t.Errorf("details of %s = %+v", name, info)
+		}
+	}
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	for _, err := range errs {
+		if !errors.Is(err, ErrModelNotFound) {
+			t.Errorf("error %v, want ErrModelNotFound", err)
+		}
+	}
+
+	peak.Store(0)
+	if _, errs := c.ShowModels(context.Background(), names[:2], 0); len(errs) != 1 || peak.Load() != 1 {
+		t.Errorf("concurrency 0: %d errors with %d requests at once, want 1 and 1", len(errs), peak.Load())
+	}
+}
+
+// TestShowModelsCanceled checks that canceling the context stops the batch
+// promptly and reports every model not fetched with the context's error.
+func TestShowModelsCanceled(t *testing.T) {
+	var peak atomic.Int32
+	srv := showServer(t, time.Minute, nil, &peak)
+	c := NewClient(srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	names := []string{"a", "b", "c", "d", "e"}
+	start := time.Now()
+	infos, errs := c.ShowModels(ctx, names, 2)
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("ShowModels took %v after cancellation", d)
+	}
+	if len(infos) != 0 || len(errs) != len(names) {
+		t.Fatalf("got %d details and %d errors, want 0 and %d", len(infos), len(errs), len(names))
+	}
+	for _, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error %v, want context.Canceled", err)
+		}
+	}
+}
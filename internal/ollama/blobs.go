@@ -0,0 +1,94 @@
+package ollama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+)
+
+// BlobExists reports whether the server already has the blob with the given
+// digest (e.g., "sha256:abc..."). It makes a HEAD request to /api/blobs/:digest.
+func (c *Client) BlobExists(ctx context.Context, digest string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.endpoint("/api/blobs/"+digest), nil)
+	if err != nil {
+		return false, err
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, newStatusError("blob", res)
+	}
+}
+
+// PushBlob uploads the contents of r as the blob with the given digest. The
+// server rejects the upload if the content does not match the digest. It
+// makes a POST request to /api/blobs/:digest and takes a transfer slot.
+func (c *Client) PushBlob(ctx context.Context, digest string, r io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint("/api/blobs/"+digest), r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	release, err := c.acquireTransfer(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer release()
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return newStatusError("blob", res)
+	}
+	return nil
+}
+
+// FileDigest returns the blob digest ("sha256:" and the hex SHA-256) of the
+// file at path, as expected by BlobExists and PushBlob.
+func FileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// UploadFile uploads the file at path as a blob unless the server already
+// has it, and returns its digest for use in CreateRequest.Files.
+func (c *Client) UploadFile(ctx context.Context, path string) (string, error) {
+	digest, err := FileDigest(path)
+	if err != nil {
+		return "", err
+	}
+	exists, err := c.BlobExists(ctx, digest)
+	if err != nil || exists {
+		return digest, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := c.PushBlob(ctx, digest, f); err != nil {
+		return "", err
+	}
+	return digest, nil
+}
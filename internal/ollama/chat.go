@@ -0,0 +1,96 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Roles of chat messages.
+const (
+	RoleSystem    = "system"    // Instructions for the model
+	RoleUser      = "user"      // Message from the user
+	RoleAssistant = "assistant" // Reply from the model
+	RoleTool      = "tool"      // Result of a tool call
+)
+
+// Message is a single message of a chat conversation.
+type Message struct {
+	Role      string     `json:"role"`                 // One of RoleSystem, RoleUser, RoleAssistant or RoleTool
+	Content   string     `json:"content"`              // Message text
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"` // Tools the assistant asked to call
+	ToolName  string     `json:"tool_name,omitempty"`  // Tool whose result a RoleTool message carries
+}
+
+// Tool describes a function the model may call (FeatureTools).
+type Tool struct {
+	Type     string       `json:"type"`     // Always "function"
+	Function ToolFunction `json:"function"` // Function definition
+}
+
+// ToolFunction is the definition of a callable function.
+type ToolFunction struct {
+	Name        string          `json:"name"`                  // Function name
+	Description string          `json:"description,omitempty"` // What the function does, for the model
+	Parameters  json.RawMessage `json:"parameters,omitempty"`  // JSON schema of the arguments
+}
+
+// ToolCall is a call of a tool emitted by the model.
+type ToolCall struct {
+	Function struct {
+		Name      string          `json:"name"`      // Function to call
+		Arguments json.RawMessage `json:"arguments"` // Arguments exactly as emitted, normally a JSON object
+	} `json:"function"`
+}
+
+// ChatRequest describes a chat completion request for /api/chat. Messages
+// carry the full conversation history, oldest first.
+type ChatRequest struct {
+	Model     string         `json:"model"`                // Model to chat with
+	Messages  []Message      `json:"messages"`             // Conversation so far
+	Options   map[string]any `json:"options,omitempty"`    // Runtime parameters such as temperature
+	KeepAlive any            `json:"keep_alive,omitempty"` // How long to keep the model loaded afterwards
+	Tools     []Tool         `json:"tools,omitempty"`      // Functions the model may call instead of answering
+
+	// Format constrains the reply: the JSON string "json" asks for any JSON
+	// value, a JSON schema object for JSON matching it (FeatureStructuredOutput).
+	Format json.RawMessage `json:"format,omitempty"`
+}
+
+// FormatJSON is the Format value asking for a reply that is any valid JSON.
+var FormatJSON = json.RawMessage(`"json"`)
+
+// ChatResponse is a single chunk of a streamed chat reply. The final chunk
+// has Done set and carries the timing statistics.
+type ChatResponse struct {
+	Model      string    `json:"model"`                 // Model that generated the chunk
+	CreatedAt  time.Time `json:"created_at"`            // When the chunk was produced
+	Message    Message   `json:"message"`               // Part of the assistant's reply
+	Done       bool      `json:"done"`                  // Whether this is the final chunk
+	DoneReason string    `json:"done_reason,omitempty"` // Why generation stopped, e.g. "stop" or "length"
+	Error      string    `json:"error,omitempty"`       // Error reported mid-stream by the server
+
+	Metrics
+}
+
+// Chat streams the model's reply to the conversation in r, calling onChunk
+// for every chunk as it arrives. It makes a POST request to /api/chat and
+// returns the statistics of the final chunk, from which callers can compute
+// tokens per second. An error returned by onChunk stops the stream.
+func (c *Client) Chat(ctx context.Context, r ChatRequest, onChunk func(ChatResponse) error) (Metrics, error) {
+	var final Metrics
+	err := c.postStream(ctx, "chat", "/api/chat", r, func(raw json.RawMessage) error {
+		var chunk ChatResponse
+		if err := json.Unmarshal(raw, &chunk); err != nil {
+			return err
+		}
+		if chunk.Done {
+			final = chunk.Metrics
+		}
+		if onChunk == nil {
+			return nil
+		}
+		return onChunk(chunk)
+	})
+	return final, err
+}
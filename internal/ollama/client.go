@@ -3,60 +3,205 @@
 package ollama
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// ErrNotSupported is returned when the server does not provide an endpoint
+// needed by the requested operation.
+var ErrNotSupported = errors.New("not supported by this server")
+
 // Client represents an HTTP client for communicating with an Ollama server.
 // It provides methods to query model information and manage model operations.
 type Client struct {
-	BaseURL string       // Base URL of the Ollama server (e.g., "http://localhost:11434")
-	HTTP    *http.Client // HTTP client for making requests
+	BaseURL  string       // Base URL of the Ollama server (e.g., "http://localhost:11434")
+	Registry string       // Base URL of the default model registry
+	HTTP     *http.Client // HTTP client for making requests
+
+	IdleTimeout      time.Duration // Longest silence tolerated on progress streams; zero disables the check
+	FirstByteTimeout time.Duration // Longest wait for a stream's first data, if longer than IdleTimeout
+	Retry            RetryPolicy   // Retries of requests failing with a transient error
+
+	Token     string      // Bearer token sent with every request, if set
+	BasicAuth *BasicAuth  // Basic auth credentials sent with every request, if set
+	Headers   http.Header // Extra headers sent with every request
+
+	transport *http.Transport // Innermost transport of HTTP, configured by SetProxy
+	socket    bool            // Whether the server is reached over a Unix socket
+	transfers chan struct{}   // Semaphore of heavy operations, nil when uncapped
+
+	registry          *http.Client    // HTTP client of registry requests, which always go over TCP
+	registryTransport *http.Transport // Innermost transport of registry, shared with HTTP over TCP
+}
+
+// BasicAuth holds HTTP basic authentication credentials.
+type BasicAuth struct {
+	User     string // User name
+	Password string // Password
+}
+
+// authorize adds the client's static headers and credentials to req.
+func (c *Client) authorize(req *http.Request) {
+	for k, vs := range c.Headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	switch {
+	case c.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	case c.BasicAuth != nil:
+		req.SetBasicAuth(c.BasicAuth.User, c.BasicAuth.Password)
+	}
 }
 
 // NewClient creates a new Ollama client with the specified base URL.
-// If base is empty, it defaults to "http://localhost:11434".
-// The HTTP client is configured with no timeout for long-running operations.
+// If base is empty, it defaults to "http://localhost:11434". Trailing slashes
+// are removed; a path prefix (e.g. "http://host/ollama") is kept. A base of
+// the form "unix:///path/to/ollama.sock" talks to the server over that Unix
+// domain socket; registry requests still go over TCP. The HTTP client is
+// configured with no timeout for long-running operations.
 func NewClient(base string) *Client {
 	if base == "" {
 		base = "http://localhost:11434"
 	}
+	transport := tcpTransport()
+	registryTransport := transport
+	path, socket := socketPath(base)
+	if socket {
+		transport = unixTransport(path)
+	}
 	return &Client{
-		BaseURL: base,
-		HTTP:    &http.Client{Timeout: 0},
+		BaseURL:  strings.TrimRight(base, "/"),
+		Registry: DefaultRegistry,
+		HTTP:     &http.Client{Timeout: 0, Transport: transport},
+
+		transport: transport,
+		socket:    socket,
+		transfers: make(chan struct{}, DefaultMaxTransfers),
+
+		registry:          &http.Client{Transport: registryTransport},
+		registryTransport: registryTransport,
+
+		IdleTimeout:      DefaultIdleTimeout,
+		FirstByteTimeout: DefaultFirstByteTimeout,
+		Retry:            DefaultRetry,
 	}
 }
 
 // Model represents an Ollama model with its metadata.
 // It contains the model name, optional digest for identification, and size in bytes.
+// Running models returned by /api/ps additionally report VRAM usage and expiry.
 type Model struct {
-	Name   string `json:"name"`             // Model name (e.g., "llama2:7b")
-	Digest string `json:"digest,omitempty"` // SHA256 digest of the model
-	Size   int64  `json:"size,omitempty"`   // Model size in bytes
+	Name      string    `json:"name"`                  // Model name (e.g., "llama2:7b")
+	Digest    string    `json:"digest,omitempty"`      // SHA256 digest of the model
+	Size      int64     `json:"size,omitempty"`        // Model size in bytes
+	Modified  time.Time `json:"modified_at,omitempty"` // When the model was last pulled or created
+	SizeVRAM  int64     `json:"size_vram,omitempty"`   // Bytes of the model held in GPU memory (running models only)
+	ExpiresAt time.Time `json:"expires_at,omitempty"`  // When the model will be unloaded (running models only)
+
+	ContextLength int64 `json:"context_length,omitempty"` // Context window of a loaded model, if the server reports it
+
+	Details ModelDetails `json:"details"` // Format, family and quantization details
+}
+
+// ModelDetails holds the descriptive details reported for each model.
+type ModelDetails struct {
+	Format            string   `json:"format,omitempty"`             // File format (e.g., "gguf")
+	Family            string   `json:"family,omitempty"`             // Model family (e.g., "llama")
+	Families          []string `json:"families,omitempty"`           // All families the model belongs to
+	ParameterSize     string   `json:"parameter_size,omitempty"`     // Parameter count (e.g., "8.0B")
+	QuantizationLevel string   `json:"quantization_level,omitempty"` // Quantization (e.g., "Q4_K_M")
+}
+
+// Processor describes where a running model is loaded, as "ollama ps" does:
+// "100% GPU", "100% CPU", or a split such as "48%/52% CPU/GPU".
+func (m Model) Processor() string {
+	if m.Size <= 0 {
+		return "-"
+	}
+	switch {
+	case m.SizeVRAM <= 0:
+		return "100% CPU"
+	case m.SizeVRAM >= m.Size:
+		return "100% GPU"
+	}
+	gpu := int(m.SizeVRAM * 100 / m.Size)
+	return fmt.Sprintf("%d%%/%d%% CPU/GPU", 100-gpu, gpu)
+}
+
+// endpoint joins the API path p onto the base URL, so that a trailing slash
+// or a path prefix in BaseURL does not produce a malformed request URL.
+func (c *Client) endpoint(p string) string {
+	base := c.BaseURL
+	if _, ok := socketPath(base); ok {
+		base = unixBase
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(p, "/")
+}
+
+// sendJSON sends in as JSON to the endpoint p using method and, unless out
+// is nil, decodes the response into out. Errors are prefixed with op. Only
+// requests whose ctx is marked idempotent are retried. A plain-text 404
+// means the server lacks the endpoint and is reported as ErrNotSupported; a
+// JSON 404 is the server's own "model not found".
+func (c *Client) sendJSON(ctx context.Context, method, op, p string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint(p), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		if mt, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mt != "application/json" {
+			return fmt.Errorf("%s: %w", op, ErrNotSupported)
+		}
+	}
+	if res.StatusCode != http.StatusOK {
+		return newStatusError(op, res)
+	}
+	if out == nil {
+		return nil
+	}
+	return decodeJSON(res, out)
 }
 
 // ListLocalModels retrieves all locally installed models from the Ollama server.
 // It makes a GET request to /api/tags and returns the list of available models.
 func (c *Client) ListLocalModels(ctx context.Context) ([]Model, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/tags", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint("/api/tags"), nil)
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.HTTP.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("tags: %s", res.Status)
+		return nil, newStatusError("tags", res)
 	}
 	var payload struct {
 		Models []Model `json:"models"`
 	}
-	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+	if err := decodeJSON(res, &payload); err != nil {
 		return nil, err
 	}
 	return payload.Models, nil
@@ -65,49 +210,271 @@ func (c *Client) ListLocalModels(ctx context.Context) ([]Model, error) {
 // ListRunning retrieves all currently running models from the Ollama server.
 // It makes a GET request to /api/ps and returns the list of active models.
 func (c *Client) ListRunning(ctx context.Context) ([]Model, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/ps", nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint("/api/ps"), nil)
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.HTTP.Do(req)
+	res, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("ps: %s", res.Status)
+		return nil, newStatusError("ps", res)
 	}
 	var payload struct {
 		Models []Model `json:"models"`
 	}
-	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+	if err := decodeJSON(res, &payload); err != nil {
 		return nil, err
 	}
 	return payload.Models, nil
 }
 
+// ModelInfo holds the metadata returned by /api/show for a single model.
+type ModelInfo struct {
+	Modelfile  string       `json:"modelfile,omitempty"`  // Modelfile the model was built from, if exposed
+	Parameters string       `json:"parameters,omitempty"` // Runtime parameters, one "name value" per line
+	Template   string       `json:"template,omitempty"`   // Prompt template
+	System     string       `json:"system,omitempty"`     // Default system prompt
+	License    string       `json:"license,omitempty"`    // License text
+	Details    ModelDetails `json:"details"`              // Format, family and quantization details
+
+	ModelInfo map[string]any `json:"model_info,omitempty"` // Architecture metadata such as "llama.context_length"
+}
+
+// ContextLength returns the context window the model was trained with, read
+// from its architecture metadata, or 0 if the server does not report it.
+func (m *ModelInfo) ContextLength() int {
+	arch, _ := m.ModelInfo["general.architecture"].(string)
+	n, _ := m.ModelInfo[arch+".context_length"].(float64)
+	return int(n)
+}
+
+// ParameterCount returns the exact number of parameters from the model's
+// metadata, or 0 if the server does not report it.
+func (m *ModelInfo) ParameterCount() int64 {
+	n, _ := m.ModelInfo["general.parameter_count"].(float64)
+	return int64(n)
+}
+
+// ShowModel retrieves metadata for the named model from the Ollama server.
+// It makes a POST request to /api/show and returns the decoded model information.
+func (c *Client) ShowModel(ctx context.Context, name string) (*ModelInfo, error) {
+	var info ModelInfo
+	in := map[string]string{"model": name}
+	if err := c.sendJSON(idempotent(ctx), http.MethodPost, "show", "/api/show", in, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// Preload loads the named model into memory without generating any output.
+// It sends an empty POST request to /api/generate. keepAlive controls how long
+// the model stays loaded afterwards, as a duration ("30s", "1h") or a number of
+// seconds ("-1" keeps it loaded indefinitely); empty uses the server default.
+func (c *Client) Preload(ctx context.Context, name, keepAlive string) error {
+	in := map[string]any{"model": name, "stream": false}
+	if keepAlive != "" {
+		in["keep_alive"] = keepAliveValue(keepAlive)
+	}
+	return c.sendJSON(ctx, http.MethodPost, "generate", "/api/generate", in, nil)
+}
+
+// Unload evicts the named model from memory by sending an empty generate
+// request with a keep-alive of zero.
+func (c *Client) Unload(ctx context.Context, name string) error {
+	return c.Preload(ctx, name, "0")
+}
+
+// DeleteModel removes the named model from the server.
+// It makes a DELETE request to /api/delete.
+func (c *Client) DeleteModel(ctx context.Context, name string) error {
+	in := map[string]string{"model": name}
+	return c.sendJSON(ctx, http.MethodDelete, "delete", "/api/delete", in, nil)
+}
+
+// CopyModel duplicates the source model under the destination name.
+// It makes a POST request to /api/copy.
+func (c *Client) CopyModel(ctx context.Context, source, destination string) error {
+	in := map[string]string{"source": source, "destination": destination}
+	return c.sendJSON(ctx, http.MethodPost, "copy", "/api/copy", in, nil)
+}
+
+// keepAliveValue converts a keep-alive setting into its JSON representation.
+// Plain integers are sent as a number of seconds, anything else as a duration string.
+func keepAliveValue(s string) any {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return s
+}
+
+// StreamLogs tails the server log, invoking fn for every line received until
+// ctx is canceled or the server closes the stream. It makes a GET request to
+// /api/logs, which only some deployments (typically behind a log-forwarding
+// proxy) provide; ErrNotSupported is returned when the endpoint is missing.
+func (c *Client) StreamLogs(ctx context.Context, fn func(line string)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint("/api/logs"), nil)
+	if err != nil {
+		return err
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return fmt.Errorf("logs: %w", ErrNotSupported)
+	default:
+		return newStatusError("logs", res)
+	}
+	sc := bufio.NewScanner(res.Body)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		fn(sc.Text())
+	}
+	if err := sc.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// ProgressResponse is a single status update streamed by long-running
+// operations such as creating or pulling a model.
+type ProgressResponse struct {
+	Status    string `json:"status"`              // Current step, e.g. "pulling manifest" or "success"
+	Digest    string `json:"digest,omitempty"`    // Blob being transferred, if any
+	Total     int64  `json:"total,omitempty"`     // Total bytes of the current blob
+	Completed int64  `json:"completed,omitempty"` // Bytes of the current blob transferred so far
+	Error     string `json:"error,omitempty"`     // Error reported mid-stream by the server
+}
+
+// CreateRequest describes a model to create, either from a Modelfile or,
+// on servers supporting FeatureStructuredCreate, from structured fields.
+type CreateRequest struct {
+	Model     string `json:"model"`               // Name of the new model
+	Modelfile string `json:"modelfile,omitempty"` // Contents of the Modelfile, for older servers
+
+	From       string            `json:"from,omitempty"`       // Existing model to derive from
+	System     string            `json:"system,omitempty"`     // System prompt
+	Template   string            `json:"template,omitempty"`   // Prompt template
+	License    []string          `json:"license,omitempty"`    // License texts
+	Parameters map[string]any    `json:"parameters,omitempty"` // Runtime parameters such as temperature
+	Messages   []Message         `json:"messages,omitempty"`   // Example conversation
+	Files      map[string]string `json:"files,omitempty"`      // GGUF or safetensors file names mapped to blob digests
+	Adapters   map[string]string `json:"adapters,omitempty"`   // LoRA adapter file names mapped to blob digests
+	Quantize   string            `json:"quantize,omitempty"`   // Quantization type, e.g. "q4_K_M"
+}
+
+// CreateModel creates a model on the server, calling progress for every status
+// update streamed back. It makes a POST request to /api/create. If the server
+// sends nothing for longer than c.IdleTimeout, it fails with ErrStreamStalled.
+func (c *Client) CreateModel(ctx context.Context, r CreateRequest, progress func(ProgressResponse)) error {
+	return c.streamProgress(ctx, "create", "/api/create", r, progress)
+}
+
+// PullRequest describes a model to download from a registry.
+type PullRequest struct {
+	Model    string `json:"model"`              // Name of the model to pull
+	Insecure bool   `json:"insecure,omitempty"` // Allow insecure connections to the registry
+}
+
+// PullModel downloads the named model, calling progress for every status
+// update streamed back with the bytes completed and total of the current
+// blob. It makes a POST request to /api/pull. If the server sends nothing for
+// longer than c.IdleTimeout, it fails with ErrStreamStalled.
+func (c *Client) PullModel(ctx context.Context, name string, progress func(ProgressResponse)) error {
+	return c.streamProgress(ctx, "pull", "/api/pull", PullRequest{Model: name}, progress)
+}
+
+// PushRequest describes a model to upload to its registry.
+type PushRequest struct {
+	Model    string `json:"model"`              // Name of the model to push, including its namespace
+	Insecure bool   `json:"insecure,omitempty"` // Allow insecure connections to the registry
+}
+
+// PushModel uploads the named model to the registry in its name, calling
+// progress for every status update streamed back. insecure allows plain HTTP
+// or unverified TLS connections to the registry. It makes a POST request to
+// /api/push. If the server sends nothing for longer than c.IdleTimeout, it
+// fails with ErrStreamStalled.
+func (c *Client) PushModel(ctx context.Context, name string, insecure bool, progress func(ProgressResponse)) error {
+	return c.streamProgress(ctx, "push", "/api/push", PushRequest{Model: name, Insecure: insecure}, progress)
+}
+
+// streamProgress posts body to the endpoint p and passes each streamed
+// progress update to progress. Errors are prefixed with op. It counts as a
+// heavy operation: while all transfer slots are taken it waits, reporting a
+// "queued" status first.
+func (c *Client) streamProgress(ctx context.Context, op, p string, body any, progress func(ProgressResponse)) error {
+	release, err := c.acquireTransfer(ctx, func() {
+		if progress != nil {
+			progress(ProgressResponse{Status: "queued"})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer release()
+	return c.postStream(ctx, op, p, body, func(raw json.RawMessage) error {
+		var pr ProgressResponse
+		if err := json.Unmarshal(raw, &pr); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(pr)
+		}
+		return nil
+	})
+}
+
 // HumanSize formats a byte count into a human-readable string.
 // It converts bytes to KiB, MiB, or GiB as appropriate, or returns "-" for zero/negative values.
+// The output matches fmt's "%.2f <unit>", e.g. "1.50 GiB".
 func HumanSize(n int64) string {
 	const (
 		kib = 1024
 		mib = kib * 1024
 		gib = mib * 1024
 	)
+	var buf [32]byte
 	switch {
 	case n >= gib:
-		return fmt.Sprintf("%.2f GiB", float64(n)/float64(gib))
+		return string(append(strconv.AppendFloat(buf[:0], float64(n)/float64(gib), 'f', 2, 64), " GiB"...))
 	case n >= mib:
-		return fmt.Sprintf("%.2f MiB", float64(n)/float64(mib))
+		return string(append(strconv.AppendFloat(buf[:0], float64(n)/float64(mib), 'f', 2, 64), " MiB"...))
 	case n >= kib:
-		return fmt.Sprintf("%.2f KiB", float64(n)/float64(kib))
+		return string(append(strconv.AppendFloat(buf[:0], float64(n)/float64(kib), 'f', 2, 64), " KiB"...))
 	case n > 0:
-		return fmt.Sprintf("%d B", n)
+		return string(append(strconv.AppendInt(buf[:0], n, 10), " B"...))
 	default:
 		return "-"
 	}
 }
 
+// HumanUntil formats the time remaining until t relative to now, such as
+// "4m30s" or "2h5m". Times in the past yield "expired", and times more than a
+// year away (a model kept loaded indefinitely) yield "forever".
+func HumanUntil(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := t.Sub(now)
+	switch {
+	case d <= 0:
+		return "expired"
+	case d > 365*24*time.Hour:
+		return "forever"
+	case d >= time.Hour:
+		return d.Round(time.Minute).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
 // WithTimeout creates a context with timeout if the duration is positive.
 // If duration is zero or negative, it returns the original context and a no-op cancel function.
 func WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
@@ -0,0 +1,196 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestEndpoint checks that API paths are joined onto base URLs with or
+// without a trailing slash and path prefix, both in the built URL and in
+// the path a server receives.
+func TestEndpoint(t *testing.T) {
+	paths := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"models":[]}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name, suffix, path string
+		want               string
+	}{
+		{"no slash", "", "/api/tags", "/api/tags"},
+		{"trailing slash", "/", "/api/tags", "/api/tags"},
+		{"double slash", "//", "/api/tags", "/api/tags"},
+		{"prefix", "/ollama", "/api/tags", "/ollama/api/tags"},
+		{"prefix with slash", "/ollama/", "/api/tags", "/ollama/api/tags"},
+		{"nested prefix", "/proxy/ollama", "/api/tags", "/proxy/ollama/api/tags"},
+		{"relative path", "/ollama", "api/tags", "/ollama/api/tags"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(srv.URL + tt.suffix)
+			if strings.HasSuffix(c.BaseURL, "/") {
+				t.Errorf("BaseURL %q keeps a trailing slash", c.BaseURL)
+			}
+			if u := c.endpoint(tt.path); u != srv.URL+tt.want {
+				t.Errorf("endpoint(%q) = %q, want %q", tt.path, u, srv.URL+tt.want)
+			}
+			if _, err := c.ListLocalModels(context.Background()); err != nil {
+				t.Fatalf("ListLocalModels: %v", err)
+			}
+			if got := <-paths; got != tt.want {
+				t.Errorf("server got path %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fmtHumanSize is the fmt-based HumanSize that the strconv version replaced.
+// Its output is the reference the faster version must keep matching.
+func fmtHumanSize(n int64) string {
+	const (
+		kib = 1024
+		mib = kib * 1024
+		gib = mib * 1024
+	)
+	switch {
+	case n >= gib:
+		return fmt.Sprintf("%.2f GiB", float64(n)/float64(gib))
+	case n >= mib:
+		return fmt.Sprintf("%.2f MiB", float64(n)/float64(mib))
+	case n >= kib:
+		return fmt.Sprintf("%.2f KiB", float64(n)/float64(kib))
+	case n > 0:
+		return fmt.Sprintf("%d B", n)
+	default:
+		return "-"
+	}
+}
+
+// humanSizes are byte counts around every unit boundary and rounding edge.
+var humanSizes = []int64{
+	math.MinInt64, -1, 0, 1, 999, 1023, 1024, 1025, 1535, 1536,
+	1<<20 - 1, 1 << 20, 1<<20 + 5242, 1<<30 - 1, 1 << 30, 1<<30 + 1,
+	2019393189, 4661224676, 1 << 40, 5<<40 + 123456789, math.MaxInt64,
+}
+
+// TestHumanSizeMatchesFmt checks that HumanSize formats exactly like the
+// fmt version it replaced.
+func TestHumanSizeMatchesFmt(t *testing.T) {
+	sizes := append([]int64(nil), humanSizes...)
+	for n := int64(1); n < math.MaxInt64/7; n = n*7 + 3 {
+		sizes = append(sizes, n)
+	}
+	for _, n := range sizes {
+		if got, want := HumanSize(n), fmtHumanSize(n); got != want {
+			t.Errorf("HumanSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+// BenchmarkHumanSize measures HumanSize, which runs for every model on every
+// redraw of the installed pane.
+func BenchmarkHumanSize(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		HumanSize(humanSizes[i%len(humanSizes)])
+	}
+}
+
+// BenchmarkHumanSizeFmt measures the fmt version for comparison.
+func BenchmarkHumanSizeFmt(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		fmtHumanSize(humanSizes[i%len(humanSizes)])
+	}
+}
+
+// TestSendJSON checks the requests the JSON endpoints send and how a
+// missing endpoint is told apart from a missing model.
+func TestSendJSON(t *testing.T) {
+	type request struct{ method, path, contentType, body string }
+	requests := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{r.Method, r.URL.Path, r.Header.Get("Content-Type"), string(body)}
+		switch r.URL.Path {
+		case "/api/show":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"details":{"family":"llama"}}`))
+		case "/api/delete", "/api/copy":
+			w.WriteHeader(http.StatusOK)
+		case "/api/embed":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"model 'nope' not found"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		call    func() error
+		want    request
+		wantErr error
+	}{
+		{
+			"show",
+			func() error {
+				info, err := c.ShowModel(ctx, "llama3.2")
+				if err == nil && info.Details.Family != "llama" {
+					err = fmt.Errorf("family %q, want llama", info.Details.Family)
+				}
+				return err
+			},
+			request{http.MethodPost, "/api/show", "application/json", `{"model":"llama3.2"}`},
+			nil,
+		},
+		{
+			"delete",
+			func() error { return c.DeleteModel(ctx, "llama3.2") },
+			request{http.MethodDelete, "/api/delete", "application/json", `{"model":"llama3.2"}`},
+			nil,
+		},
+		{
+			"copy",
+			func() error { return c.CopyModel(ctx, "llama3.2", "mine") },
+			request{http.MethodPost, "/api/copy", "application/json", `{"destination":"mine","source":"llama3.2"}`},
+			nil,
+		},
+		{
+			"model not found",
+			func() error { _, err := c.Embed(ctx, "nope", []string{"hi"}); return err },
+			request{http.MethodPost, "/api/embed", "application/json", `{"input":["hi"],"model":"nope"}`},
+			ErrModelNotFound,
+		},
+		{
+			"endpoint not found",
+			func() error { _, err := c.Tokenize(ctx, "llama3.2", "hi"); return err },
+			request{http.MethodPost, "/api/tokenize", "application/json", `{"content":"hi","model":"llama3.2"}`},
+			ErrNotSupported,
+		},
+	}
+	for _, tt := range tests {
+		err := tt.call()
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if got := <-requests; got != tt.want {
+			t.Errorf("%s: sent %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
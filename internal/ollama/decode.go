@@ -0,0 +1,38 @@
+package ollama
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// snippetLen is the number of body bytes quoted in unexpected-response errors.
+const snippetLen = 200
+
+// decodeJSON decodes a JSON response body into v. If the response declares a
+// non-JSON content type, as a proxy login or error page would, it returns an
+// error naming the content type and quoting the start of the body instead of
+// a confusing JSON syntax error.
+func decodeJSON(res *http.Response, v any) error {
+	if ct := res.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mt != "application/json" && !strings.HasSuffix(mt, "+json")) {
+			return fmt.Errorf("expected JSON but got %s; is there a proxy/login page? body: %q", ct, bodySnippet(res.Body))
+		}
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// bodySnippet returns the first snippetLen bytes of r with whitespace collapsed.
+func bodySnippet(r io.Reader) string {
+	buf := make([]byte, snippetLen)
+	n, _ := io.ReadFull(r, buf)
+	s := strings.Join(strings.Fields(string(buf[:n])), " ")
+	if n == snippetLen {
+		s += "…"
+	}
+	return s
+}
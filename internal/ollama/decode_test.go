@@ -0,0 +1,61 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDecodeHTMLResponse checks that an HTML page answering with 200, as a
+// proxy login page does, is reported with its content type and the start of
+// its body rather than as a JSON syntax error.
+func TestDecodeHTMLResponse(t *testing.T) {
+	page := "<!DOCTYPE html>\n<html>\n  <head><title>Sign in</title></head>\n  <body>Please log in to continue.</body>\n</html>\n"
+	tests := []struct {
+		name, body, want string
+	}{
+		{"short page", page, `"<!DOCTYPE html> <html> <head><title>Sign in</title></head> <body>Please log in to continue.</body> </html>"`},
+		{"long page", page + strings.Repeat("<p>filler</p>\n", 50), "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL).ListLocalModels(context.Background())
+			if err == nil {
+				t.Fatal("ListLocalModels succeeded on an HTML page")
+			}
+			msg := err.Error()
+			for _, want := range []string{"text/html; charset=utf-8", "<title>Sign in</title>", tt.want} {
+				if !strings.Contains(msg, want) {
+					t.Errorf("error %q does not contain %q", msg, want)
+				}
+			}
+			if strings.Contains(msg, "invalid character") {
+				t.Errorf("error %q is a JSON syntax error", msg)
+			}
+		})
+	}
+}
+
+// TestDecodeJSONContentTypes checks that JSON bodies are decoded whatever
+// JSON media type the server declares.
+func TestDecodeJSONContentTypes(t *testing.T) {
+	for _, ct := range []string{"application/json", "application/json; charset=utf-8", "application/problem+json"} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", ct)
+			w.Write([]byte(`{"models":[{"name":"llama3.2:latest"}]}`))
+		}))
+		models, err := NewClient(srv.URL).ListLocalModels(context.Background())
+		srv.Close()
+		if err != nil || len(models) != 1 {
+			t.Errorf("Content-Type %q: got %d models, error %v; want 1 model", ct, len(models), err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package ollama
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// Embed computes an embedding vector for each input using the named model.
+// It makes a POST request to /api/embed; vectors are returned in input order.
+func (c *Client) Embed(ctx context.Context, model string, input []string) ([][]float32, error) {
+	var out struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	in := map[string]any{"model": model, "input": input}
+	if err := c.sendJSON(idempotent(ctx), http.MethodPost, "embed", "/api/embed", in, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Embeddings) != len(input) {
+		return nil, fmt.Errorf("embed: got %d embeddings for %d inputs", len(out.Embeddings), len(input))
+	}
+	return out.Embeddings, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, in
+// [-1, 1]. It returns 0 if the vectors differ in length or either is zero.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,68 @@
+package ollama
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Errors reported by the client. StatusError values match ErrModelNotFound
+// and ErrUnauthorized with errors.Is according to their status code.
+var (
+	ErrModelNotFound     = errors.New("model not found")
+	ErrServerUnreachable = errors.New("server unreachable")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrUnrecognizedPage  = errors.New("unrecognized page, the site may have changed")
+)
+
+// StatusError is returned when the server answers with an unexpected HTTP status.
+type StatusError struct {
+	Op      string // Operation that failed, e.g. "pull"
+	Code    int    // HTTP status code
+	Status  string // HTTP status line, e.g. "404 Not Found"
+	Message string // Error message from the response body, if any
+}
+
+// Error formats the operation, status and server message.
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: %s", e.Op, e.Status)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Op, e.Status, e.Message)
+}
+
+// Is makes a StatusError match the sentinel error for its status code.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrModelNotFound:
+		return e.Code == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Code == http.StatusUnauthorized || e.Code == http.StatusForbidden
+	}
+	return false
+}
+
+// Temporary reports whether retrying the request may succeed, i.e. whether
+// the server reported a transient failure.
+func (e *StatusError) Temporary() bool {
+	return e.Code >= 500 || e.Code == http.StatusTooManyRequests
+}
+
+// newStatusError builds a StatusError for res, taking the message from an
+// Ollama-style {"error": "..."} body or, failing that, a body snippet.
+func newStatusError(op string, res *http.Response) *StatusError {
+	e := &StatusError{Op: op, Code: res.StatusCode, Status: res.Status}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		e.Message = payload.Error
+	} else {
+		e.Message = bodySnippet(strings.NewReader(string(body)))
+	}
+	return e
+}
@@ -0,0 +1,66 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// GenerateRequest describes a completion request for /api/generate.
+type GenerateRequest struct {
+	Model     string         `json:"model"`                // Model to generate with
+	Prompt    string         `json:"prompt"`               // Prompt text; empty only loads the model
+	System    string         `json:"system,omitempty"`     // System prompt overriding the model's default
+	Template  string         `json:"template,omitempty"`   // Prompt template overriding the model's default
+	Raw       bool           `json:"raw,omitempty"`        // Send the prompt without applying the template
+	Options   map[string]any `json:"options,omitempty"`    // Runtime parameters such as temperature
+	KeepAlive any            `json:"keep_alive,omitempty"` // How long to keep the model loaded afterwards
+}
+
+// GenerateResponse is a single chunk of a streamed completion. The final
+// chunk has Done set and carries the timing statistics.
+type GenerateResponse struct {
+	Model      string    `json:"model"`                 // Model that generated the chunk
+	CreatedAt  time.Time `json:"created_at"`            // When the chunk was produced
+	Response   string    `json:"response"`              // Generated text of this chunk
+	Done       bool      `json:"done"`                  // Whether this is the final chunk
+	DoneReason string    `json:"done_reason,omitempty"` // Why generation stopped, e.g. "stop" or "length"
+	Error      string    `json:"error,omitempty"`       // Error reported mid-stream by the server
+
+	Metrics
+}
+
+// Metrics are the timing statistics reported with the final chunk of a
+// generate or chat stream. Durations are in nanoseconds.
+type Metrics struct {
+	TotalDuration      time.Duration `json:"total_duration,omitempty"`       // Time spent on the whole request
+	LoadDuration       time.Duration `json:"load_duration,omitempty"`        // Time spent loading the model
+	PromptEvalCount    int           `json:"prompt_eval_count,omitempty"`    // Tokens in the prompt
+	PromptEvalDuration time.Duration `json:"prompt_eval_duration,omitempty"` // Time spent evaluating the prompt
+	EvalCount          int           `json:"eval_count,omitempty"`           // Tokens generated
+	EvalDuration       time.Duration `json:"eval_duration,omitempty"`        // Time spent generating
+}
+
+// TokensPerSecond returns the generation speed, or 0 if it is unknown.
+func (m Metrics) TokensPerSecond() float64 {
+	if m.EvalDuration <= 0 {
+		return 0
+	}
+	return float64(m.EvalCount) / m.EvalDuration.Seconds()
+}
+
+// Generate streams a completion for r, calling fn for every chunk as it
+// arrives. It makes a POST request to /api/generate. An error returned by fn
+// stops the stream and is returned, wrapped.
+func (c *Client) Generate(ctx context.Context, r GenerateRequest, fn func(GenerateResponse) error) error {
+	return c.postStream(ctx, "generate", "/api/generate", r, func(raw json.RawMessage) error {
+		var chunk GenerateResponse
+		if err := json.Unmarshal(raw, &chunk); err != nil {
+			return err
+		}
+		if fn == nil {
+			return nil
+		}
+		return fn(chunk)
+	})
+}
@@ -0,0 +1,83 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultIdleTimeout is how long a progress stream may go without sending
+// any data before it is considered dead.
+const DefaultIdleTimeout = 2 * time.Minute
+
+// DefaultFirstByteTimeout is how long a stream may take to send its first
+// data. It is longer than the idle timeout as the server loads the model,
+// or resolves a pull's manifest, before it responds.
+const DefaultFirstByteTimeout = 10 * time.Minute
+
+// ErrStreamStalled is returned when a streaming response sends nothing for
+// longer than the client's idle timeout.
+var ErrStreamStalled = errors.New("stream stalled")
+
+// idleWatch cancels a request once its response has been silent for longer
+// than the timeout. Until the first chunk arrives the longer first-byte
+// timeout applies; after that the timer is reset on every chunk read, so
+// slow but steady transfers are not interrupted.
+type idleWatch struct {
+	timer   *time.Timer // Fires when the stream has been idle too long; nil if disabled
+	timeout time.Duration
+	fired   atomic.Bool // Whether the timer cancelled the request
+}
+
+// watchIdle returns a context derived from ctx that is cancelled when the
+// stream sends nothing for c.FirstByteTimeout, or once it has started, stays
+// idle for c.IdleTimeout. A non-positive IdleTimeout disables both.
+func (c *Client) watchIdle(ctx context.Context) (context.Context, *idleWatch, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &idleWatch{timeout: c.IdleTimeout}
+	if w.timeout > 0 {
+		w.timer = time.AfterFunc(max(c.FirstByteTimeout, w.timeout), func() {
+			w.fired.Store(true)
+			cancel()
+		})
+	}
+	return ctx, w, func() {
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+		cancel()
+	}
+}
+
+// reader wraps r so that every successful read resets the idle timer.
+func (w *idleWatch) reader(r io.Reader) io.Reader {
+	if w.timer == nil {
+		return r
+	}
+	return idleReader{r: r, w: w}
+}
+
+// err replaces err with ErrStreamStalled if the idle timer cancelled the request.
+func (w *idleWatch) err(err error) error {
+	if err != nil && w.fired.Load() {
+		return ErrStreamStalled
+	}
+	return err
+}
+
+// idleReader resets its watch's timer whenever data arrives.
+type idleReader struct {
+	r io.Reader
+	w *idleWatch
+}
+
+// Read reads from the underlying reader and resets the idle timer on progress.
+func (r idleReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.w.timer.Reset(r.w.timeout)
+	}
+	return n, err
+}
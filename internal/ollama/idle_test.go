@@ -0,0 +1,58 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestIdleTimeout checks that the idle timer lets a stream take up to the
+// first-byte timeout to send its first chunk, as while the server loads a
+// model, but fails a stream that goes silent once started.
+func TestIdleTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstByte time.Duration // Delay before the first chunk
+		stall     time.Duration // Delay before the second chunk
+		wantErr   error
+	}{
+		{"slow first chunk", 300 * time.Millisecond, 0, nil},
+		{"stalled after first chunk", 0, 2 * time.Second, ErrStreamStalled},
+		{"no first chunk", 3 * time.Second, 0, ErrStreamStalled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+				for _, delay := range []time.Duration{tt.firstByte, tt.stall} {
+					select {
+					case <-time.After(delay):
+					case <-r.Context().Done():
+						return
+					}
+					w.Write([]byte(`{"status":"pulling manifest"}` + "\n"))
+					w.(http.Flusher).Flush()
+				}
+				w.Write([]byte(`{"status":"success"}` + "\n"))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL)
+			c.IdleTimeout = 100 * time.Millisecond
+			c.FirstByteTimeout = time.Second
+			var updates int
+			err := c.PullModel(context.Background(), "llama3.2", func(ProgressResponse) { updates++ })
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PullModel = %v after %d updates, want %v", err, updates, tt.wantErr)
+			}
+			if err == nil && updates != 3 {
+				t.Errorf("got %d progress updates, want 3", updates)
+			}
+		})
+	}
+}
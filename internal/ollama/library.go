@@ -0,0 +1,253 @@
+package ollama
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultLibrary is the website hosting the public model library.
+const DefaultLibrary = "https://ollama.com"
+
+// Library searches the public model library on ollama.com and lists the
+// tags of its models. The site has no JSON API, so results are scraped from
+// its search and tag pages.
+type Library struct {
+	BaseURL string       // Base URL of the library website
+	HTTP    *http.Client // HTTP client for making requests
+
+	transport *http.Transport // Transport of HTTP, configured by SetProxy and ConfigureTLS
+}
+
+// NewLibrary returns a client for the public model library. It honors
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY unless SetProxy overrides them.
+func NewLibrary() *Library {
+	transport := tcpTransport()
+	return &Library{
+		BaseURL:   DefaultLibrary,
+		HTTP:      &http.Client{Timeout: 15 * time.Second, Transport: transport},
+		transport: transport,
+	}
+}
+
+// LibraryModel is a model listed in the public library.
+type LibraryModel struct {
+	Name         string   // Name to pull, e.g. "llama3.2" or "user/model"
+	Description  string   // Short description
+	Sizes        []string // Parameter sizes offered, e.g. "1b", "3b"
+	Capabilities []string // Capabilities such as "tools" or "vision"
+	Pulls        string   // Pull count as displayed, e.g. "12.3M"
+	Tags         string   // Number of tags as displayed
+	Updated      string   // When the model was last updated, e.g. "2 weeks ago"
+}
+
+// Patterns matching the marked-up fields of a search result. A search page
+// always has the results list, even when nothing matched.
+var (
+	libraryListRe   = regexp.MustCompile(`\bid="searchresults"`)
+	libraryItemRe   = regexp.MustCompile(`<li[^>]*\bx-test-model\b`)
+	libraryHrefRe   = regexp.MustCompile(`href="/([^"?#]+)"`)
+	libraryTitleRe  = regexp.MustCompile(`x-test-search-response-title[^>]*>([^<]*)<`)
+	libraryDescRe   = regexp.MustCompile(`<p[^>]*>([^<]+)</p>`)
+	librarySizeRe   = regexp.MustCompile(`x-test-size[^>]*>([^<]*)<`)
+	libraryCapRe    = regexp.MustCompile(`x-test-capability[^>]*>([^<]*)<`)
+	libraryPullsRe  = regexp.MustCompile(`x-test-pull-count[^>]*>([^<]*)<`)
+	libraryTagsRe   = regexp.MustCompile(`x-test-tag-count[^>]*>([^<]*)<`)
+	libraryUpdateRe = regexp.MustCompile(`x-test-updated[^>]*>([^<]*)<`)
+)
+
+// Search returns the library models matching query, most popular first. An
+// empty query lists the most popular models.
+func (l *Library) Search(ctx context.Context, query string) ([]LibraryModel, error) {
+	u := strings.TrimRight(l.BaseURL, "/") + "/search?q=" + url.QueryEscape(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	res, err := l.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, newStatusError("library search", res)
+	}
+	page, err := io.ReadAll(io.LimitReader(res.Body, 8<<20))
+	if err != nil {
+		return nil, fmt.Errorf("library search: %w", err)
+	}
+	models, err := parseLibraryPage(string(page))
+	if err != nil {
+		return nil, fmt.Errorf("library search: %w", err)
+	}
+	return models, nil
+}
+
+// parseLibraryPage extracts the search results from a library search page.
+// It returns ErrUnrecognizedPage if the page lacks the results list, or
+// lists results none of which could be read, rather than reporting no
+// matches for markup it does not understand.
+func parseLibraryPage(page string) ([]LibraryModel, error) {
+	if !libraryListRe.MatchString(page) {
+		return nil, ErrUnrecognizedPage
+	}
+	starts := libraryItemRe.FindAllStringIndex(page, -1)
+	models := make([]LibraryModel, 0, len(starts))
+	for i, start := range starts {
+		end := len(page)
+		if i+1 < len(starts) {
+			end = starts[i+1][0]
+		}
+		item := page[start[0]:end]
+		m := LibraryModel{
+			Name:         firstMatch(libraryTitleRe, item),
+			Sizes:        allMatches(librarySizeRe, item),
+			Capabilities: allMatches(libraryCapRe, item),
+			Pulls:        firstMatch(libraryPullsRe, item),
+			Tags:         firstMatch(libraryTagsRe, item),
+			Updated:      firstMatch(libraryUpdateRe, item),
+		}
+		if href := firstMatch(libraryHrefRe, item); href != "" {
+			m.Name = strings.TrimPrefix(href, "library/")
+		}
+		if m.Name == "" {
+			continue
+		}
+		m.Description = firstMatch(libraryDescRe, item)
+		models = append(models, m)
+	}
+	if len(starts) > 0 && len(models) == 0 {
+		return nil, ErrUnrecognizedPage
+	}
+	return models, nil
+}
+
+// firstMatch returns the unescaped, trimmed first group of re's first
+// match in s, or "" if there is none.
+func firstMatch(re *regexp.Regexp, s string) string {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(m[1]))
+}
+
+// allMatches returns the unescaped, trimmed first group of every match of
+// re in s.
+func allMatches(re *regexp.Regexp, s string) []string {
+	var out []string
+	for _, m := range re.FindAllStringSubmatch(s, -1) {
+		if v := strings.TrimSpace(html.UnescapeString(m[1])); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// LibraryTag is a tag of a library model.
+type LibraryTag struct {
+	Name   string // Full name to pull, e.g. "llama3.2:3b-instruct-q8_0"
+	Size   string // Download size as displayed, e.g. "3.4GB"
+	Digest string // Abbreviated manifest digest, if shown
+}
+
+// Patterns matching the fields of a tag listing. Tag links have the form
+// href="/<page>:<tag>", where page is e.g. "library/llama3.2".
+var (
+	libraryTagHrefRe  = regexp.MustCompile(`href="/([^":]+):([^"]+)"`)
+	librarySizeTextRe = regexp.MustCompile(`\b\d+(?:\.\d+)?\s?[KMGT]B\b`)
+	libraryDigestRe   = regexp.MustCompile(`\b[0-9a-f]{12}\b`)
+)
+
+// PageURL returns the library web page of the named model, e.g.
+// "https://ollama.com/library/llama3.2" for "llama3.2:3b". Any tag in name
+// is ignored.
+func (l *Library) PageURL(name string) (string, error) {
+	ref := ParseModelRef(name)
+	if ref.Host != "" {
+		return "", fmt.Errorf("page of %s: %w", name, ErrNotSupported)
+	}
+	return strings.TrimRight(l.BaseURL, "/") + "/" + ref.Namespace + "/" + ref.Model, nil
+}
+
+// Tags returns the tags of the named library model, e.g. "llama3.2" or
+// "user/model", in the order the library lists them. Any tag in name is
+// ignored.
+func (l *Library) Tags(ctx context.Context, name string) ([]LibraryTag, error) {
+	ref := ParseModelRef(name)
+	if ref.Host != "" {
+		return nil, fmt.Errorf("tags of %s: %w", name, ErrNotSupported)
+	}
+	page := ref.Namespace + "/" + ref.Model
+	u := strings.TrimRight(l.BaseURL, "/") + "/" + page + "/tags"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	res, err := l.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, newStatusError("library tags", res)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, 8<<20))
+	if err != nil {
+		return nil, fmt.Errorf("library tags: %w", err)
+	}
+	tags, err := parseTagsPage(string(body), page)
+	if err != nil {
+		return nil, fmt.Errorf("library tags: %w", err)
+	}
+	return tags, nil
+}
+
+// parseTagsPage extracts the tags of the model at page (e.g.
+// "library/llama3.2") from its tag listing. Every model has at least one
+// tag, so a page without any returns ErrUnrecognizedPage.
+func parseTagsPage(body, page string) ([]LibraryTag, error) {
+	model := strings.TrimPrefix(page, "library/")
+	var matches [][]int
+	for _, m := range libraryTagHrefRe.FindAllStringSubmatchIndex(body, -1) {
+		if body[m[2]:m[3]] == page {
+			matches = append(matches, m)
+		}
+	}
+	seen := make(map[string]int, len(matches))
+	var tags []LibraryTag
+	for i, m := range matches {
+		end := len(body)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		segment := body[m[1]:end]
+		tag := html.UnescapeString(body[m[4]:m[5]])
+		j, ok := seen[tag]
+		if !ok {
+			j = len(tags)
+			seen[tag] = j
+			tags = append(tags, LibraryTag{Name: model + ":" + tag})
+		}
+		// A tag may be linked several times; take each field from the
+		// first link followed by it.
+		if tags[j].Size == "" {
+			tags[j].Size = librarySizeTextRe.FindString(segment)
+		}
+		if tags[j].Digest == "" {
+			tags[j].Digest = libraryDigestRe.FindString(segment)
+		}
+	}
+	if len(tags) == 0 {
+		return nil, ErrUnrecognizedPage
+	}
+	return tags, nil
+}
@@ -0,0 +1,141 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readFixture returns the contents of the named file in testdata.
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// TestParseLibraryPage checks the fields scraped from a saved search page,
+// including a user model without a description or sizes.
+func TestParseLibraryPage(t *testing.T) {
+	got, err := parseLibraryPage(readFixture(t, "library_search.html"))
+	if err != nil {
+		t.Fatalf("parseLibraryPage: %v", err)
+	}
+	want := []LibraryModel{
+		{
+			Name:         "llama3.2",
+			Description:  "Meta's Llama 3.2 goes small with 1B and 3B models.",
+			Sizes:        []string{"1b", "3b"},
+			Capabilities: []string{"tools"},
+			Pulls:        "21.5M",
+			Tags:         "63",
+			Updated:      "10 months ago",
+		},
+		{
+			Name:         "llava",
+			Description:  "LLaVA is a novel end-to-end trained large multimodal model.",
+			Sizes:        []string{"7b", "13b", "34b"},
+			Capabilities: []string{"vision"},
+			Pulls:        "8.4M",
+			Tags:         "98",
+			Updated:      "1 year ago",
+		},
+		{
+			Name:    "jmorgan/tinyllama-ft",
+			Pulls:   "1,204",
+			Tags:    "2",
+			Updated: "3 weeks ago",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLibraryPage =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+// TestParseLibraryPageUnrecognized checks that a search without matches is
+// an empty list, but markup the scraper does not understand is an error.
+func TestParseLibraryPageUnrecognized(t *testing.T) {
+	got, err := parseLibraryPage(readFixture(t, "library_search_empty.html"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("empty search = %v, %v; want no models and no error", got, err)
+	}
+
+	tests := []struct {
+		name string
+		page string
+	}{
+		{"empty", ""},
+		{"changed markup", readFixture(t, "library_changed.html")},
+		{"unreadable items", `<ul id="searchresults"><li x-test-model><span>llama3.2</span></li></ul>`},
+	}
+	for _, tt := range tests {
+		if got, err := parseLibraryPage(tt.page); !errors.Is(err, ErrUnrecognizedPage) {
+			t.Errorf("%s: parseLibraryPage = %v, %v; want ErrUnrecognizedPage", tt.name, got, err)
+		}
+	}
+}
+
+// TestSearchUnrecognized checks that Search reports a page it cannot parse
+// instead of returning no results.
+func TestSearchUnrecognized(t *testing.T) {
+	page := readFixture(t, "library_changed.html")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("q") != "llama" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	}))
+	defer srv.Close()
+
+	l := NewLibrary()
+	l.BaseURL = srv.URL
+	if got, err := l.Search(context.Background(), "llama"); !errors.Is(err, ErrUnrecognizedPage) {
+		t.Errorf("Search = %v, %v; want ErrUnrecognizedPage", got, err)
+	}
+}
+
+// TestParseTagsPage checks the tags scraped from a saved tags page: each
+// tag once, in page order, with its size and digest, and links to other
+// models ignored.
+func TestParseTagsPage(t *testing.T) {
+	got, err := parseTagsPage(readFixture(t, "library_tags.html"), "library/llama3.2")
+	if err != nil {
+		t.Fatalf("parseTagsPage: %v", err)
+	}
+	want := []LibraryTag{
+		{Name: "llama3.2:latest", Size: "2.0GB", Digest: "a80c4f17acd5"},
+		{Name: "llama3.2:1b", Size: "1.3GB", Digest: "baf6a787fdff"},
+		{Name: "llama3.2:3b-instruct-q8_0", Size: "3.4GB", Digest: "e410b836fe61"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTagsPage =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+// TestParseTagsPageUnrecognized checks that a page without tags of the
+// model is an error rather than an empty list.
+func TestParseTagsPageUnrecognized(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		page string
+	}{
+		{"empty", "", "library/llama3.2"},
+		{"changed markup", readFixture(t, "library_changed.html"), "library/llama3.2"},
+		{"other model", readFixture(t, "library_tags.html"), "library/qwen2.5"},
+	}
+	for _, tt := range tests {
+		if got, err := parseTagsPage(tt.body, tt.page); !errors.Is(err, ErrUnrecognizedPage) {
+			t.Errorf("%s: parseTagsPage = %v, %v; want ErrUnrecognizedPage", tt.name, got, err)
+		}
+	}
+}
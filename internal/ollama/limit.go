@@ -0,0 +1,42 @@
+package ollama
+
+import "context"
+
+// DefaultMaxTransfers is the default number of heavy operations (pulls,
+// pushes, creates and blob uploads) the client runs at the same time.
+const DefaultMaxTransfers = 2
+
+// SetMaxTransfers caps the number of heavy operations running at once;
+// further ones wait for a free slot. Zero or less removes the cap. Listing
+// and other light requests are never throttled. It must be called before the
+// client is used.
+func (c *Client) SetMaxTransfers(n int) {
+	if n <= 0 {
+		c.transfers = nil
+		return
+	}
+	c.transfers = make(chan struct{}, n)
+}
+
+// acquireTransfer waits for a free heavy-operation slot and returns the
+// function releasing it. If a slot is not free right away, waiting is called
+// once first so callers can report that the operation is queued.
+func (c *Client) acquireTransfer(ctx context.Context, waiting func()) (release func(), err error) {
+	if c.transfers == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.transfers <- struct{}{}:
+		return func() { <-c.transfers }, nil
+	default:
+	}
+	if waiting != nil {
+		waiting()
+	}
+	select {
+	case c.transfers <- struct{}{}:
+		return func() { <-c.transfers }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
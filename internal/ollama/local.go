@@ -0,0 +1,85 @@
+package ollama
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultModelsDir returns the directory the Ollama server stores models in:
+// $OLLAMA_MODELS if set, otherwise ~/.ollama/models.
+func DefaultModelsDir() string {
+	if dir := os.Getenv("OLLAMA_MODELS"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ollama", "models")
+}
+
+// IsLocal reports whether the client talks to a server on this machine,
+// in which case the models directory is accessible from the filesystem.
+func (c *Client) IsLocal() bool {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "unix" {
+		return true
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ManifestPath returns the path of the on-disk manifest for the named model.
+func ManifestPath(modelsDir, name string) string {
+	ref := ParseModelRef(name)
+	host := ref.Host
+	if host == "" {
+		host = strings.TrimPrefix(DefaultRegistry, "https://")
+	}
+	return filepath.Join(modelsDir, "manifests", host, filepath.FromSlash(ref.Namespace), ref.Model, ref.Tag)
+}
+
+// BlobPath returns the path of the blob with the given digest
+// (e.g., "sha256:abc...") under modelsDir.
+func BlobPath(modelsDir, digest string) string {
+	return filepath.Join(modelsDir, "blobs", strings.Replace(digest, ":", "-", 1))
+}
+
+// BlobPaths reads the local manifest of the named model and returns the
+// paths of its config and layer blobs under modelsDir.
+func BlobPaths(modelsDir, name string) ([]string, error) {
+	data, err := os.ReadFile(ManifestPath(modelsDir, name))
+	if err != nil {
+		return nil, err
+	}
+	var manifest struct {
+		Config struct {
+			Digest string `json:"digest"`
+		} `json:"config"`
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	var paths []string
+	if manifest.Config.Digest != "" {
+		paths = append(paths, BlobPath(modelsDir, manifest.Config.Digest))
+	}
+	for _, l := range manifest.Layers {
+		paths = append(paths, BlobPath(modelsDir, l.Digest))
+	}
+	return paths, nil
+}
@@ -0,0 +1,376 @@
+package ollama
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MockClient is an in-memory API implementation with scripted responses,
+// for testing application logic without a live server. Operations act on
+// the model lists like a real server would: pulling adds a model, deleting
+// removes it, preloading makes it running. Errors scripted for a method
+// name are returned before any state changes. Callbacks are called without
+// the mock locked, so they may call the mock themselves.
+type MockClient struct {
+	mu sync.Mutex
+
+	Installed []Model               // Models returned by ListLocalModels
+	Running   []Model               // Models returned by ListRunning
+	Infos     map[string]*ModelInfo // Details returned by ShowModel, keyed by model name
+	Digests   map[string]string     // Remote digests returned by RemoteDigest, keyed by model name
+	Progress  []ProgressResponse    // Updates streamed by PullModel, PushModel and CreateModel
+	Reply     string                // Text streamed word by word by Generate and Chat
+	ToolCalls []ToolCall            // Tool calls Chat replies with when the request offers tools
+	Logs      []string              // Lines streamed by StreamLogs
+	Server    string                // Version returned by Version
+	Local     bool                  // Value returned by IsLocal
+
+	Errors map[string]error // Errors to return, keyed by method name, e.g. "PullModel"
+	Calls  []string         // Method names in the order they were called
+}
+
+var _ API = (*MockClient)(nil)
+
+// call records a call to method and returns the error scripted for it.
+func (m *MockClient) call(method string) error {
+	m.Calls = append(m.Calls, method)
+	return m.Errors[method]
+}
+
+// index returns the position of the named model in models, or -1.
+func index(models []Model, name string) int {
+	for i, mod := range models {
+		if SameModel(mod.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ListLocalModels returns a copy of Installed.
+func (m *MockClient) ListLocalModels(context.Context) ([]Model, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("ListLocalModels"); err != nil {
+		return nil, err
+	}
+	return append([]Model(nil), m.Installed...), nil
+}
+
+// ListRunning returns a copy of Running.
+func (m *MockClient) ListRunning(context.Context) ([]Model, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("ListRunning"); err != nil {
+		return nil, err
+	}
+	return append([]Model(nil), m.Running...), nil
+}
+
+// ShowModel returns the scripted details of an installed model.
+func (m *MockClient) ShowModel(_ context.Context, name string) (*ModelInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("ShowModel"); err != nil {
+		return nil, err
+	}
+	if index(m.Installed, name) < 0 {
+		return nil, &StatusError{Op: "show", Code: 404, Status: "404 Not Found", Message: "model not found"}
+	}
+	if info, ok := m.Infos[name]; ok {
+		return info, nil
+	}
+	return &ModelInfo{}, nil
+}
+
+// ShowModels calls ShowModel for each of names, using at most concurrency
+// goroutines.
+func (m *MockClient) ShowModels(ctx context.Context, names []string, concurrency int) (map[string]*ModelInfo, []error) {
+	return showModels(ctx, m, names, concurrency)
+}
+
+// Version returns Server.
+func (m *MockClient) Version(context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Version"); err != nil {
+		return "", err
+	}
+	return m.Server, nil
+}
+
+// IsLocal returns Local.
+func (m *MockClient) IsLocal() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Local
+}
+
+// startStream records a call to method and returns a copy of Progress, so
+// that the updates can be sent without holding the lock.
+func (m *MockClient) startStream(method string) ([]ProgressResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call(method); err != nil {
+		return nil, err
+	}
+	return append([]ProgressResponse(nil), m.Progress...), nil
+}
+
+// stream sends updates to progress.
+func stream(updates []ProgressResponse, progress func(ProgressResponse)) {
+	if progress == nil {
+		return
+	}
+	for _, p := range updates {
+		progress(p)
+	}
+}
+
+// PullModel streams Progress and adds the model to Installed.
+func (m *MockClient) PullModel(_ context.Context, name string, progress func(ProgressResponse)) error {
+	updates, err := m.startStream("PullModel")
+	if err != nil {
+		return err
+	}
+	stream(updates, progress)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if index(m.Installed, name) < 0 {
+		m.Installed = append(m.Installed, Model{Name: NormalizeName(name)})
+	}
+	return nil
+}
+
+// PushModel streams Progress.
+func (m *MockClient) PushModel(_ context.Context, _ string, _ bool, progress func(ProgressResponse)) error {
+	updates, err := m.startStream("PushModel")
+	if err != nil {
+		return err
+	}
+	stream(updates, progress)
+	return nil
+}
+
+// CreateModel streams Progress and adds the new model to Installed.
+func (m *MockClient) CreateModel(_ context.Context, r CreateRequest, progress func(ProgressResponse)) error {
+	updates, err := m.startStream("CreateModel")
+	if err != nil {
+		return err
+	}
+	stream(updates, progress)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if index(m.Installed, r.Model) < 0 {
+		m.Installed = append(m.Installed, Model{Name: NormalizeName(r.Model)})
+	}
+	return nil
+}
+
+// CopyModel adds a copy of source named destination to Installed.
+func (m *MockClient) CopyModel(_ context.Context, source, destination string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("CopyModel"); err != nil {
+		return err
+	}
+	i := index(m.Installed, source)
+	if i < 0 {
+		return &StatusError{Op: "copy", Code: 404, Status: "404 Not Found", Message: "model not found"}
+	}
+	cp := m.Installed[i]
+	cp.Name = NormalizeName(destination)
+	if j := index(m.Installed, destination); j >= 0 {
+		m.Installed[j] = cp
+	} else {
+		m.Installed = append(m.Installed, cp)
+	}
+	return nil
+}
+
+// DeleteModel removes the model from Installed and Running.
+func (m *MockClient) DeleteModel(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("DeleteModel"); err != nil {
+		return err
+	}
+	i := index(m.Installed, name)
+	if i < 0 {
+		return &StatusError{Op: "delete", Code: 404, Status: "404 Not Found", Message: "model not found"}
+	}
+	m.Installed = append(m.Installed[:i], m.Installed[i+1:]...)
+	if j := index(m.Running, name); j >= 0 {
+		m.Running = append(m.Running[:j], m.Running[j+1:]...)
+	}
+	return nil
+}
+
+// RemoteDigest returns the scripted digest for the model.
+func (m *MockClient) RemoteDigest(_ context.Context, name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("RemoteDigest"); err != nil {
+		return "", err
+	}
+	d, ok := m.Digests[name]
+	if !ok {
+		return "", fmt.Errorf("manifest: %w", ErrModelNotFound)
+	}
+	return d, nil
+}
+
+// Preload adds the model to Running.
+func (m *MockClient) Preload(_ context.Context, name, _ string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Preload"); err != nil {
+		return err
+	}
+	i := index(m.Installed, name)
+	if i < 0 {
+		return &StatusError{Op: "generate", Code: 404, Status: "404 Not Found", Message: "model not found"}
+	}
+	if index(m.Running, name) < 0 {
+		m.Running = append(m.Running, m.Installed[i])
+	}
+	return nil
+}
+
+// Unload removes the model from Running.
+func (m *MockClient) Unload(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Unload"); err != nil {
+		return err
+	}
+	if i := index(m.Running, name); i >= 0 {
+		m.Running = append(m.Running[:i], m.Running[i+1:]...)
+	}
+	return nil
+}
+
+// replyWords splits Reply into chunks that join back to the original text.
+func (m *MockClient) replyWords() []string {
+	words := strings.SplitAfter(m.Reply, " ")
+	if len(words) == 1 && words[0] == "" {
+		return nil
+	}
+	return words
+}
+
+// Generate streams Reply word by word.
+func (m *MockClient) Generate(_ context.Context, r GenerateRequest, fn func(GenerateResponse) error) error {
+	m.mu.Lock()
+	err := m.call("Generate")
+	words := m.replyWords()
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	for i, w := range words {
+		chunk := GenerateResponse{Model: r.Model, Response: w, Done: i == len(words)-1}
+		if chunk.Done {
+			chunk.EvalCount = len(words)
+		}
+		if fn != nil {
+			if err := fn(chunk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Chat streams Reply word by word as the assistant's message, or replies
+// with ToolCalls if they are set and the request offers tools.
+func (m *MockClient) Chat(_ context.Context, r ChatRequest, onChunk func(ChatResponse) error) (Metrics, error) {
+	m.mu.Lock()
+	err := m.call("Chat")
+	calls := append([]ToolCall(nil), m.ToolCalls...)
+	words := m.replyWords()
+	m.mu.Unlock()
+	if err != nil {
+		return Metrics{}, err
+	}
+	if len(r.Tools) > 0 && len(calls) > 0 {
+		chunk := ChatResponse{Model: r.Model, Message: Message{Role: RoleAssistant, ToolCalls: calls}, Done: true}
+		if onChunk != nil {
+			return Metrics{}, onChunk(chunk)
+		}
+		return Metrics{}, nil
+	}
+	final := Metrics{EvalCount: len(words)}
+	for i, w := range words {
+		chunk := ChatResponse{Model: r.Model, Message: Message{Role: RoleAssistant, Content: w}, Done: i == len(words)-1}
+		if chunk.Done {
+			chunk.Metrics = final
+		}
+		if onChunk != nil {
+			if err := onChunk(chunk); err != nil {
+				return final, err
+			}
+		}
+	}
+	return final, nil
+}
+
+// Embed returns a small vector per input derived from its length, so that
+// equal inputs yield equal vectors.
+func (m *MockClient) Embed(_ context.Context, _ string, input []string) ([][]float32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Embed"); err != nil {
+		return nil, err
+	}
+	out := make([][]float32, len(input))
+	for i, s := range input {
+		out[i] = []float32{float32(len(s)), 1, float32(strings.Count(s, " "))}
+	}
+	return out, nil
+}
+
+// Tokenize returns one token per rune: the rune's code point.
+func (m *MockClient) Tokenize(_ context.Context, _ string, text string) ([]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Tokenize"); err != nil {
+		return nil, err
+	}
+	tokens := make([]int, 0, len(text))
+	for _, r := range text {
+		tokens = append(tokens, int(r))
+	}
+	return tokens, nil
+}
+
+// Detokenize reverses Tokenize.
+func (m *MockClient) Detokenize(_ context.Context, _ string, tokens []int) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.call("Detokenize"); err != nil {
+		return "", err
+	}
+	runes := make([]rune, len(tokens))
+	for i, t := range tokens {
+		runes[i] = rune(t)
+	}
+	return string(runes), nil
+}
+
+// StreamLogs sends Logs line by line.
+func (m *MockClient) StreamLogs(_ context.Context, fn func(line string)) error {
+	m.mu.Lock()
+	lines := append([]string(nil), m.Logs...)
+	err := m.call("StreamLogs")
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, l := range lines {
+		fn(l)
+	}
+	return nil
+}
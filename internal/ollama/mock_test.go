@@ -0,0 +1,89 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMockCallbacksUnlocked checks that the mock's streaming methods call
+// their callbacks without holding its lock, so a callback can call the mock
+// again, as the application does when it refreshes on progress.
+func TestMockCallbacksUnlocked(t *testing.T) {
+	m := &MockClient{
+		Installed: []Model{{Name: "llama3.2:latest"}},
+		Progress:  []ProgressResponse{{Status: "pulling manifest"}, {Status: "success"}},
+		Reply:     "Hello there",
+	}
+	var call ToolCall
+	call.Function.Name = "now"
+	m.ToolCalls = []ToolCall{call}
+	ctx := context.Background()
+	reenter := func() {
+		if _, err := m.ListLocalModels(ctx); err != nil {
+			t.Errorf("ListLocalModels from a callback: %v", err)
+		}
+	}
+	progress := func(ProgressResponse) { reenter() }
+	calls := map[string]func() error{
+		"PullModel":   func() error { return m.PullModel(ctx, "qwen2.5:7b", progress) },
+		"PushModel":   func() error { return m.PushModel(ctx, "user/llama3.2", false, progress) },
+		"CreateModel": func() error { return m.CreateModel(ctx, CreateRequest{Model: "mine"}, progress) },
+		"Generate": func() error {
+			return m.Generate(ctx, GenerateRequest{Model: "llama3.2"}, func(GenerateResponse) error { reenter(); return nil })
+		},
+		"Chat": func() error {
+			_, err := m.Chat(ctx, ChatRequest{Model: "llama3.2"}, func(ChatResponse) error { reenter(); return nil })
+			return err
+		},
+		"Chat with tools": func() error {
+			_, err := m.Chat(ctx, ChatRequest{Model: "llama3.2", Tools: []Tool{{Type: "function"}}}, func(ChatResponse) error { reenter(); return nil })
+			return err
+		},
+		"StreamLogs": func() error { return m.StreamLogs(ctx, func(string) { reenter() }) },
+	}
+	m.Logs = []string{"time=... level=INFO msg=\"starting\""}
+	for name, fn := range calls {
+		done := make(chan error, 1)
+		go func() { done <- fn() }()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s deadlocked calling back into the mock", name)
+		}
+	}
+
+	installed, _ := m.ListLocalModels(ctx)
+	var names []string
+	for _, mod := range installed {
+		names = append(names, mod.Name)
+	}
+	sort.Strings(names)
+	if got, want := strings.Join(names, " "), "llama3.2:latest mine:latest qwen2.5:7b"; got != want {
+		t.Errorf("installed after pull and create = %q, want %q", got, want)
+	}
+}
+
+// TestMockScriptedError checks that a scripted error is returned before any
+// progress is sent or state changes.
+func TestMockScriptedError(t *testing.T) {
+	boom := errors.New("boom")
+	m := &MockClient{
+		Progress: []ProgressResponse{{Status: "pulling manifest"}},
+		Errors:   map[string]error{"PullModel": boom},
+	}
+	var updates int
+	err := m.PullModel(context.Background(), "llama3.2", func(ProgressResponse) { updates++ })
+	if !errors.Is(err, boom) || updates != 0 || len(m.Installed) != 0 {
+		t.Errorf("PullModel = %v after %d updates with %d installed, want boom, 0 and 0", err, updates, len(m.Installed))
+	}
+	if len(m.Calls) != 1 || m.Calls[0] != "PullModel" {
+		t.Errorf("Calls = %q, want [PullModel]", m.Calls)
+	}
+}
@@ -0,0 +1,23 @@
+package ollama
+
+import "strings"
+
+// NormalizeName returns the canonical form of a model name for comparisons:
+// surrounding whitespace is trimmed, letters are lowercased, and the implicit
+// ":latest" tag is added when the name has no tag. Names may contain
+// slashes, colons and spaces; only a colon after the last slash is a tag.
+func NormalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ""
+	}
+	if strings.LastIndex(name, ":") <= strings.LastIndex(name, "/") {
+		name += ":latest"
+	}
+	return name
+}
+
+// SameModel reports whether two model names refer to the same model.
+func SameModel(a, b string) bool {
+	return NormalizeName(a) == NormalizeName(b)
+}
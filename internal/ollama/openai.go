@@ -0,0 +1,420 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAIClient talks to servers exposing only the OpenAI-compatible API,
+// such as the llama.cpp server, vLLM or LM Studio. It lists models through
+// /v1/models and chats through /v1/chat/completions; model management,
+// which has no OpenAI equivalent, fails with ErrNotSupported.
+type OpenAIClient struct {
+	c *Client // Underlying client providing transport, auth and retries
+}
+
+var _ API = (*OpenAIClient)(nil)
+
+// NewOpenAIClient returns an OpenAI-compatible adapter sending its requests
+// through c, so that c's proxy, TLS, authentication and retry settings apply.
+func NewOpenAIClient(c *Client) *OpenAIClient {
+	return &OpenAIClient{c: c}
+}
+
+// openAIModel is an entry of the /v1/models response.
+type openAIModel struct {
+	ID      string `json:"id"`       // Model name
+	Created int64  `json:"created"`  // Creation time in Unix seconds
+	OwnedBy string `json:"owned_by"` // Owner reported by the server
+}
+
+// openAIChunk is a single server-sent event of a streamed chat completion.
+type openAIChunk struct {
+	Model   string `json:"model"` // Model that generated the chunk
+	Choices []struct {
+		Delta struct {
+			Content   string `json:"content"` // Part of the assistant's reply
+			ToolCalls []struct {
+				Index    int `json:"index"` // Position of the call the fragment belongs to
+				Function struct {
+					Name      string `json:"name"`      // Function name, sent with the first fragment
+					Arguments string `json:"arguments"` // Next fragment of the JSON-encoded arguments
+				} `json:"function"`
+			} `json:"tool_calls"` // Fragments of tool calls
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"` // Why generation stopped, set on the last chunk
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`     // Tokens in the prompt
+		CompletionTokens int `json:"completion_tokens"` // Tokens generated
+	} `json:"usage"`
+}
+
+// models fetches the model list from /v1/models.
+func (o *OpenAIClient) models(ctx context.Context) ([]openAIModel, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.c.endpoint("/v1/models"), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := o.c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, newStatusError("models", res)
+	}
+	var payload struct {
+		Data []openAIModel `json:"data"`
+	}
+	if err := decodeJSON(res, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Data, nil
+}
+
+// ListLocalModels returns the models served by the server. Only names and
+// creation times are known.
+func (o *OpenAIClient) ListLocalModels(ctx context.Context) ([]Model, error) {
+	data, err := o.models(ctx)
+	if err != nil {
+		return nil, err
+	}
+	models := make([]Model, 0, len(data))
+	for _, m := range data {
+		model := Model{Name: m.ID}
+		if m.Created > 0 {
+			model.Modified = time.Unix(m.Created, 0)
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+// ListRunning returns no models: the OpenAI API does not report which
+// models are loaded.
+func (o *OpenAIClient) ListRunning(ctx context.Context) ([]Model, error) {
+	return nil, nil
+}
+
+// ShowModel returns the little the OpenAI API knows about a model, failing
+// with ErrModelNotFound if the server does not list it.
+func (o *OpenAIClient) ShowModel(ctx context.Context, name string) (*ModelInfo, error) {
+	data, err := o.models(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range data {
+		if m.ID == name {
+			return &ModelInfo{}, nil
+		}
+	}
+	return nil, fmt.Errorf("show %s: %w", name, ErrModelNotFound)
+}
+
+// ShowModels calls ShowModel for each of names, using at most concurrency
+// simultaneous requests.
+func (o *OpenAIClient) ShowModels(ctx context.Context, names []string, concurrency int) (map[string]*ModelInfo, []error) {
+	return showModels(ctx, o, names, concurrency)
+}
+
+// Version checks that the server answers and returns an empty version,
+// since the OpenAI API does not report one. An empty version is treated as
+// supporting every feature.
+func (o *OpenAIClient) Version(ctx context.Context) (string, error) {
+	if _, err := o.models(ctx); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// IsLocal reports false: the server's files are not laid out like Ollama's,
+// so local-only features never apply.
+func (o *OpenAIClient) IsLocal() bool {
+	return false
+}
+
+// unsupported returns the error of an operation the OpenAI API lacks.
+func unsupported(op string) error {
+	return fmt.Errorf("%s: %w", op, ErrNotSupported)
+}
+
+// PullModel is not supported by the OpenAI API.
+func (o *OpenAIClient) PullModel(context.Context, string, func(ProgressResponse)) error {
+	return unsupported("pull")
+}
+
+// PushModel is not supported by the OpenAI API.
+func (o *OpenAIClient) PushModel(context.Context, string, bool, func(ProgressResponse)) error {
+	return unsupported("push")
+}
+
+// CreateModel is not supported by the OpenAI API.
+func (o *OpenAIClient) CreateModel(context.Context, CreateRequest, func(ProgressResponse)) error {
+	return unsupported("create")
+}
+
+// CopyModel is not supported by the OpenAI API.
+func (o *OpenAIClient) CopyModel(context.Context, string, string) error {
+	return unsupported("copy")
+}
+
+// DeleteModel is not supported by the OpenAI API.
+func (o *OpenAIClient) DeleteModel(context.Context, string) error {
+	return unsupported("delete")
+}
+
+// RemoteDigest is not supported: models are not pulled from a registry.
+func (o *OpenAIClient) RemoteDigest(context.Context, string) (string, error) {
+	return "", unsupported("update check")
+}
+
+// Preload is not supported by the OpenAI API.
+func (o *OpenAIClient) Preload(context.Context, string, string) error {
+	return unsupported("load")
+}
+
+// Unload is not supported by the OpenAI API.
+func (o *OpenAIClient) Unload(context.Context, string) error {
+	return unsupported("unload")
+}
+
+// Embed is not supported by this adapter.
+func (o *OpenAIClient) Embed(context.Context, string, []string) ([][]float32, error) {
+	return nil, unsupported("embed")
+}
+
+// Tokenize is not supported by the OpenAI API.
+func (o *OpenAIClient) Tokenize(context.Context, string, string) ([]int, error) {
+	return nil, unsupported("tokenize")
+}
+
+// Detokenize is not supported by the OpenAI API.
+func (o *OpenAIClient) Detokenize(context.Context, string, []int) (string, error) {
+	return "", unsupported("detokenize")
+}
+
+// StreamLogs is not supported by the OpenAI API.
+func (o *OpenAIClient) StreamLogs(context.Context, func(string)) error {
+	return unsupported("logs")
+}
+
+// Generate completes r.Prompt by sending it, with r.System, as a chat
+// conversation. The final chunk carries the token counts and the total
+// duration; the server does not report evaluation times.
+func (o *OpenAIClient) Generate(ctx context.Context, r GenerateRequest, fn func(GenerateResponse) error) error {
+	var messages []Message
+	if r.System != "" {
+		messages = append(messages, Message{Role: RoleSystem, Content: r.System})
+	}
+	messages = append(messages, Message{Role: RoleUser, Content: r.Prompt})
+	_, err := o.Chat(ctx, ChatRequest{Model: r.Model, Messages: messages, Options: r.Options}, func(chunk ChatResponse) error {
+		if fn == nil {
+			return nil
+		}
+		return fn(GenerateResponse{
+			Model:      chunk.Model,
+			CreatedAt:  chunk.CreatedAt,
+			Response:   chunk.Message.Content,
+			Done:       chunk.Done,
+			DoneReason: chunk.DoneReason,
+			Metrics:    chunk.Metrics,
+		})
+	})
+	return err
+}
+
+// Chat streams the reply to the conversation in r from
+// /v1/chat/completions, calling onChunk for every chunk. The temperature,
+// top_p and seed options are passed through; other options are ignored. A
+// Format is sent as the equivalent response_format.
+func (o *OpenAIClient) Chat(ctx context.Context, r ChatRequest, onChunk func(ChatResponse) error) (Metrics, error) {
+	body := map[string]any{
+		"model":          r.Model,
+		"messages":       openAIMessages(r.Messages),
+		"stream":         true,
+		"stream_options": map[string]any{"include_usage": true},
+	}
+	if len(r.Tools) > 0 {
+		body["tools"] = r.Tools
+	}
+	for _, k := range []string{"temperature", "top_p", "seed"} {
+		if v, ok := r.Options[k]; ok {
+			body[k] = v
+		}
+	}
+	switch {
+	case len(r.Format) == 0:
+	case bytes.Equal(r.Format, FormatJSON):
+		body["response_format"] = map[string]any{"type": "json_object"}
+	default:
+		body["response_format"] = map[string]any{
+			"type":        "json_schema",
+			"json_schema": map[string]any{"name": "response", "schema": r.Format},
+		}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return Metrics{}, err
+	}
+	ctx, idle, stop := o.c.watchIdle(ctx)
+	defer stop()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.c.endpoint("/v1/chat/completions"), bytes.NewReader(data))
+	if err != nil {
+		return Metrics{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	start := time.Now()
+	res, err := o.c.do(req)
+	if err != nil {
+		return Metrics{}, fmt.Errorf("chat: %w", idle.err(err))
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return Metrics{}, newStatusError("chat", res)
+	}
+	var final Metrics
+	var reason string
+	var calls []openAIToolCall
+	err = sseDecode(ctx, idle.reader(res.Body), func(raw json.RawMessage) error {
+		var chunk openAIChunk
+		if err := json.Unmarshal(raw, &chunk); err != nil {
+			return err
+		}
+		if chunk.Usage != nil {
+			final.PromptEvalCount = chunk.Usage.PromptTokens
+			final.EvalCount = chunk.Usage.CompletionTokens
+		}
+		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil {
+				reason = *choice.FinishReason
+			}
+			for _, tc := range choice.Delta.ToolCalls {
+				for len(calls) <= tc.Index {
+					calls = append(calls, openAIToolCall{})
+				}
+				calls[tc.Index].name += tc.Function.Name
+				calls[tc.Index].args.WriteString(tc.Function.Arguments)
+			}
+			if onChunk == nil || choice.Delta.Content == "" {
+				continue
+			}
+			msg := Message{Role: RoleAssistant, Content: choice.Delta.Content}
+			if err := onChunk(ChatResponse{Model: chunk.Model, CreatedAt: time.Now(), Message: msg}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Metrics{}, fmt.Errorf("chat: %w", idle.err(err))
+	}
+	final.TotalDuration = time.Since(start)
+	if onChunk != nil {
+		done := ChatResponse{Model: r.Model, CreatedAt: time.Now(), Done: true, DoneReason: reason, Metrics: final}
+		done.Message = Message{Role: RoleAssistant}
+		for _, c := range calls {
+			done.Message.ToolCalls = append(done.Message.ToolCalls, c.toolCall())
+		}
+		if err := onChunk(done); err != nil {
+			return Metrics{}, err
+		}
+	}
+	return final, nil
+}
+
+// openAIToolCall accumulates the fragments of a streamed tool call.
+type openAIToolCall struct {
+	name string          // Function name
+	args strings.Builder // JSON-encoded arguments received so far
+}
+
+// toolCall converts the accumulated call. Arguments that are not valid JSON
+// are kept as a JSON string so that they can still be shown.
+func (c *openAIToolCall) toolCall() ToolCall {
+	var tc ToolCall
+	tc.Function.Name = c.name
+	args := c.args.String()
+	if json.Valid([]byte(args)) {
+		tc.Function.Arguments = json.RawMessage(args)
+	} else {
+		tc.Function.Arguments, _ = json.Marshal(args)
+	}
+	return tc
+}
+
+// openAIMessages converts messages to the OpenAI format, in which tool call
+// arguments are JSON-encoded strings and tool results refer to their call by
+// ID. Calls get sequential IDs and results answer them in order.
+func openAIMessages(messages []Message) []map[string]any {
+	out := make([]map[string]any, 0, len(messages))
+	var pending []string
+	n := 0
+	for _, m := range messages {
+		msg := map[string]any{"role": m.Role, "content": m.Content}
+		if len(m.ToolCalls) > 0 {
+			var calls []map[string]any
+			for _, tc := range m.ToolCalls {
+				n++
+				id := fmt.Sprintf("call_%d", n)
+				pending = append(pending, id)
+				calls = append(calls, map[string]any{
+					"id":       id,
+					"type":     "function",
+					"function": map[string]any{"name": tc.Function.Name, "arguments": string(tc.Function.Arguments)},
+				})
+			}
+			msg["tool_calls"] = calls
+		}
+		if m.Role == RoleTool && len(pending) > 0 {
+			msg["tool_call_id"] = pending[0]
+			pending = pending[1:]
+		}
+		out = append(out, msg)
+	}
+	return out
+}
+
+// sseDecode reads a server-sent event stream from r and calls fn with the
+// data of each event until the "[DONE]" sentinel or the end of the stream.
+// Events carrying an "error" object end the stream with its message.
+func sseDecode(ctx context.Context, r io.Reader, fn func(json.RawMessage) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, ok := strings.CutPrefix(sc.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return nil
+		}
+		var status struct {
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &status); err != nil {
+			return fmt.Errorf("invalid event %q: %w", data, err)
+		}
+		if status.Error != nil {
+			return errors.New(status.Error.Message)
+		}
+		if err := fn(json.RawMessage(data)); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
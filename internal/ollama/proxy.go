@@ -0,0 +1,45 @@
+package ollama
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// tcpTransport returns the transport used for servers reached over TCP.
+// Like http.DefaultTransport it honors HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+func tcpTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	return t
+}
+
+// SetProxy routes all requests through the proxy at u instead of the one
+// named by the environment; a nil u restores the environment proxy. Requests
+// to a server reached over a Unix socket are not proxied, but its registry
+// requests are. It must be called before the client is used.
+func (c *Client) SetProxy(u *url.URL) {
+	proxy := http.ProxyFromEnvironment
+	if u != nil {
+		proxy = http.ProxyURL(u)
+	}
+	if c.transport != nil && !c.socket {
+		c.transport.Proxy = proxy
+	}
+	if c.registryTransport != nil {
+		c.registryTransport.Proxy = proxy
+	}
+}
+
+// SetProxy routes the library client's requests through the proxy at u
+// instead of the one named by the environment; a nil u restores the
+// environment proxy. It must be called before the client is used.
+func (l *Library) SetProxy(u *url.URL) {
+	if l.transport == nil {
+		return
+	}
+	if u == nil {
+		l.transport.Proxy = http.ProxyFromEnvironment
+		return
+	}
+	l.transport.Proxy = http.ProxyURL(u)
+}
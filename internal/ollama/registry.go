@@ -0,0 +1,119 @@
+package ollama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultRegistry is the registry used for model names without an explicit host.
+const DefaultRegistry = "https://registry.ollama.ai"
+
+// manifestMediaType is the media type Ollama registries serve manifests as.
+const manifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+// ModelRef is a model name split into its registry components.
+type ModelRef struct {
+	Host      string // Registry host, empty for the default registry
+	Namespace string // Namespace, "library" for official models
+	Model     string // Model repository name
+	Tag       string // Tag, "latest" when omitted
+}
+
+// ParseModelRef splits a model name such as "llama3", "user/model:7b" or
+// "registry.example.com/team/model:q4" into its components.
+func ParseModelRef(name string) ModelRef {
+	ref := ModelRef{Namespace: "library", Tag: "latest"}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	parts := strings.Split(name, "/")
+	switch len(parts) {
+	case 1:
+		ref.Model = parts[0]
+	case 2:
+		ref.Namespace, ref.Model = parts[0], parts[1]
+	default:
+		ref.Host = parts[0]
+		ref.Namespace = strings.Join(parts[1:len(parts)-1], "/")
+		ref.Model = parts[len(parts)-1]
+	}
+	return ref
+}
+
+// manifestURL returns the registry URL of the manifest for ref.
+func (c *Client) manifestURL(ref ModelRef) string {
+	base := c.Registry
+	if ref.Host != "" {
+		base = "https://" + ref.Host
+	}
+	if base == "" {
+		base = DefaultRegistry
+	}
+	return fmt.Sprintf("%s/v2/%s/%s/manifests/%s", strings.TrimRight(base, "/"), ref.Namespace, ref.Model, ref.Tag)
+}
+
+// registryClient returns the HTTP client of registry requests. Unlike
+// c.HTTP it never dials the server's Unix socket.
+func (c *Client) registryClient() *http.Client {
+	if c.registry == nil {
+		return http.DefaultClient
+	}
+	return c.registry
+}
+
+// RemoteDigest returns the digest of the named model's manifest in its
+// registry, which is comparable to Model.Digest of a local model. It asks
+// with a HEAD request first and only downloads the manifest to hash it if
+// the registry does not report the digest in a header.
+func (c *Client) RemoteDigest(ctx context.Context, name string) (string, error) {
+	u := c.manifestURL(ParseModelRef(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", manifestMediaType)
+	res, err := c.registryClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", newStatusError("manifest", res)
+	}
+	if digest := res.Header.Get("Docker-Content-Digest"); digest != "" {
+		return strings.TrimPrefix(digest, "sha256:"), nil
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", manifestMediaType)
+	res, err = c.registryClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", newStatusError("manifest", res)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, res.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SameDigest reports whether two digests refer to the same content,
+// ignoring an optional "sha256:" prefix and letter case.
+func SameDigest(a, b string) bool {
+	a = strings.TrimPrefix(strings.ToLower(a), "sha256:")
+	b = strings.TrimPrefix(strings.ToLower(b), "sha256:")
+	return a != "" && a == b
+}
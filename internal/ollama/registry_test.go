@@ -0,0 +1,74 @@
+package ollama
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// TestRemoteDigest checks that the manifest digest is taken from the
+// Docker-Content-Digest header when the registry sends it and computed from
+// the manifest otherwise, for servers reached over TCP and over a Unix
+// socket alike.
+func TestRemoteDigest(t *testing.T) {
+	const manifest = `{"schemaVersion":2,"layers":[]}`
+	sum := sha256.Sum256([]byte(manifest))
+	hashed := hex.EncodeToString(sum[:])
+	const headerDigest = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name   string
+		header bool
+		want   string
+	}{
+		{"header", true, headerDigest},
+		{"hashed manifest", false, hashed},
+	}
+	for _, tt := range tests {
+		registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v2/library/llama3.2/manifests/1b" {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Header.Get("Accept") != manifestMediaType {
+				t.Errorf("%s request Accept = %q, want %q", r.Method, r.Header.Get("Accept"), manifestMediaType)
+			}
+			if tt.header {
+				w.Header().Set("Docker-Content-Digest", "sha256:"+headerDigest)
+			}
+			w.Header().Set("Content-Type", manifestMediaType)
+			if r.Method == http.MethodGet {
+				w.Write([]byte(manifest))
+			}
+		}))
+		for _, base := range []string{"http://127.0.0.1:1", "unix://" + filepath.Join(t.TempDir(), "missing.sock")} {
+			c := NewClient(base)
+			c.Registry = registry.URL
+			got, err := c.RemoteDigest(context.Background(), "llama3.2:1b")
+			if err != nil {
+				t.Errorf("%s via %s: RemoteDigest: %v", tt.name, base, err)
+				continue
+			}
+			if got != tt.want {
+				t.Errorf("%s via %s: RemoteDigest = %q, want %q", tt.name, base, got, tt.want)
+			}
+		}
+		registry.Close()
+	}
+}
+
+// TestRemoteDigestMissing checks that a manifest the registry does not have
+// is reported as an error.
+func TestRemoteDigestMissing(t *testing.T) {
+	registry := httptest.NewServer(http.NotFoundHandler())
+	defer registry.Close()
+	c := NewClient("")
+	c.Registry = registry.URL
+	if digest, err := c.RemoteDigest(context.Background(), "user/missing"); err == nil {
+		t.Errorf("RemoteDigest = %q, want an error", digest)
+	}
+}
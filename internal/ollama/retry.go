@@ -0,0 +1,133 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how requests failing with a transient error are
+// retried: failed or reset connections, timeouts, 5xx and 429 responses.
+// Only GET and HEAD requests, and requests marked as idempotent, are
+// retried.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts per request; 1 or less disables retries
+	BaseDelay   time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay    time.Duration // Upper bound for a single delay
+}
+
+// DefaultRetry is the retry policy of clients created with NewClient.
+var DefaultRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: 250 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// noRetryKey is the context key set by WithoutRetry.
+type noRetryKey struct{}
+
+// idempotentKey is the context key set by idempotent.
+type idempotentKey struct{}
+
+// WithoutRetry returns a context whose requests are sent once, regardless
+// of the client's retry policy, for callers that retry on their own.
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// idempotent returns a context whose requests may be retried although their
+// method is not GET or HEAD, for POST endpoints that only read or compute,
+// such as /api/show.
+func idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// retryable reports whether req may be sent more than once: its method or
+// context marks it as idempotent, its body can be replayed and its context
+// does not come from WithoutRetry.
+func retryable(req *http.Request) bool {
+	ctx := req.Context()
+	if ctx.Value(noRetryKey{}) != nil || (req.Body != nil && req.GetBody == nil) {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return ctx.Value(idempotentKey{}) != nil
+}
+
+// unreachable reports whether a transport error means the server could not
+// be reached or dropped the connection: a failed dial or DNS lookup, a
+// refused or reset connection, or a timeout. TLS verification failures and
+// malformed requests are not, since retrying cannot fix them.
+func unreachable(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// delay returns the jittered wait before retry number n (starting at 0):
+// the exponential delay scaled by a random factor between 0.5 and 1.5.
+func (p RetryPolicy) delay(n int) time.Duration {
+	d := p.BaseDelay << n
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	return time.Duration(float64(d) * (0.5 + rand.Float64()))
+}
+
+// do sends req with the client's credentials and headers, retrying
+// transient failures of retryable requests according to c.Retry. Transport
+// failures that mean the server is unreachable are marked as
+// ErrServerUnreachable, unless they were caused by the request's context
+// ending. After the last attempt a temporary error response is returned as
+// is.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 || !retryable(req) {
+		attempts = 1
+	}
+	c.authorize(req)
+	ctx := req.Context()
+	for n := 0; ; n++ {
+		if n > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		res, err := c.HTTP.Do(req)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		if err != nil && !unreachable(err) {
+			return nil, err
+		}
+		last := n == attempts-1
+		if err == nil && (last || !(&StatusError{Code: res.StatusCode}).Temporary()) {
+			return res, nil
+		}
+		if err != nil && last {
+			return nil, fmt.Errorf("%w: %w", ErrServerUnreachable, err)
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.Retry.delay(n)):
+		}
+	}
+}
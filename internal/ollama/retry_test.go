@@ -0,0 +1,125 @@
+package ollama
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWithoutRetry checks that requests with a WithoutRetry context are
+// sent once while others follow the retry policy.
+func TestWithoutRetry(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	c.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want int32
+	}{
+		{"policy", context.Background(), 3},
+		{"without retry", WithoutRetry(context.Background()), 1},
+	}
+	for _, tt := range tests {
+		hits.Store(0)
+		if _, err := c.ListLocalModels(tt.ctx); err == nil {
+			t.Errorf("%s: ListLocalModels succeeded against a failing server", tt.name)
+		}
+		if got := hits.Load(); got != tt.want {
+			t.Errorf("%s: server hit %d times, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestRetryOnlyIdempotent checks that 5xx responses are retried for reads
+// and idempotent POSTs but not for requests that change the server, such as
+// loading a model, which would otherwise be attempted again after an OOM.
+func TestRetryOnlyIdempotent(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"model requires more system memory"}`))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	c.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want int32
+	}{
+		{"list", func() error { _, err := c.ListLocalModels(ctx); return err }, 3},
+		{"show", func() error { _, err := c.ShowModel(ctx, "llama3.2"); return err }, 3},
+		{"embed", func() error { _, err := c.Embed(ctx, "llama3.2", []string{"hi"}); return err }, 3},
+		{"tokenize", func() error { _, err := c.Tokenize(ctx, "llama3.2", "hi"); return err }, 3},
+		{"preload", func() error { return c.Preload(ctx, "llama3.2", "") }, 1},
+		{"unload", func() error { return c.Unload(ctx, "llama3.2") }, 1},
+		{"delete", func() error { return c.DeleteModel(ctx, "llama3.2") }, 1},
+		{"copy", func() error { return c.CopyModel(ctx, "llama3.2", "copy") }, 1},
+		{"pull", func() error { return c.PullModel(ctx, "llama3.2", func(ProgressResponse) {}) }, 1},
+	}
+	for _, tt := range tests {
+		hits.Store(0)
+		if err := tt.call(); err == nil {
+			t.Errorf("%s succeeded against a failing server", tt.name)
+		}
+		if got := hits.Load(); got != tt.want {
+			t.Errorf("%s: server hit %d times, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestDoTransportErrors checks that only failures to reach the server are
+// reported as ErrServerUnreachable, and that others fail at once.
+func TestDoTransportErrors(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	tlsSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsSrv.Close()
+
+	tests := []struct {
+		name        string
+		base        string
+		unreachable bool
+	}{
+		{"connection refused", closed.URL, true},
+		{"unknown host", "http://host.invalid", true},
+		{"untrusted certificate", tlsSrv.URL, false},
+		{"unsupported scheme", "ftp://localhost:11434", false},
+	}
+	for _, tt := range tests {
+		c := NewClient(tt.base)
+		// A retry would take seconds; unreachable servers are tried once to
+		// keep the test fast, which still wraps the error.
+		c.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: 2 * time.Second, MaxDelay: 2 * time.Second}
+		if tt.unreachable {
+			c.Retry.MaxAttempts = 1
+		}
+		start := time.Now()
+		_, err := c.Version(context.Background())
+		if err == nil {
+			t.Errorf("%s: Version succeeded", tt.name)
+			continue
+		}
+		if got := errors.Is(err, ErrServerUnreachable); got != tt.unreachable {
+			t.Errorf("%s: errors.Is(%v, ErrServerUnreachable) = %v, want %v", tt.name, err, got, tt.unreachable)
+		}
+		if !tt.unreachable && time.Since(start) >= time.Second {
+			t.Errorf("%s: took %v, want no retries", tt.name, time.Since(start))
+		}
+	}
+}
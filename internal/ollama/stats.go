@@ -0,0 +1,57 @@
+package ollama
+
+import "sort"
+
+// FamilyOther is the family reported for models without family information.
+const FamilyOther = "other"
+
+// Family returns the model's family, or FamilyOther if it is unknown.
+func (m Model) Family() string {
+	if m.Details.Family == "" {
+		return FamilyOther
+	}
+	return m.Details.Family
+}
+
+// ModelStats summarizes a list of models.
+type ModelStats struct {
+	Count       int            // Number of models
+	TotalSize   int64          // Sum of model sizes in bytes
+	AverageSize int64          // Mean model size in bytes, 0 for an empty list
+	Largest     *Model         // Largest model by size, nil for an empty list
+	Families    map[string]int // Number of models per family; models without one count as FamilyOther
+}
+
+// Stats computes aggregate statistics for models.
+func Stats(models []Model) ModelStats {
+	st := ModelStats{Count: len(models), Families: make(map[string]int)}
+	for i := range models {
+		m := &models[i]
+		st.TotalSize += m.Size
+		if st.Largest == nil || m.Size > st.Largest.Size {
+			st.Largest = m
+		}
+		st.Families[m.Family()]++
+	}
+	if st.Count > 0 {
+		st.AverageSize = st.TotalSize / int64(st.Count)
+	}
+	return st
+}
+
+// FamilyNames returns the families in st ordered by descending model count,
+// then by name.
+func (st ModelStats) FamilyNames() []string {
+	names := make([]string, 0, len(st.Families))
+	for name := range st.Families {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ci, cj := st.Families[names[i]], st.Families[names[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,81 @@
+package ollama
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestStats checks the totals, average, largest model and family counts,
+// including an empty list and a tie for the largest model.
+func TestStats(t *testing.T) {
+	model := func(name, family string, size int64) Model {
+		return Model{Name: name, Size: size, Details: ModelDetails{Family: family}}
+	}
+	tests := []struct {
+		name     string
+		models   []Model
+		want     ModelStats
+		largest  string   // Name of the largest model, "" for none
+		families []string // Expected FamilyNames order
+	}{
+		{
+			name:     "empty",
+			want:     ModelStats{Families: map[string]int{}},
+			families: []string{},
+		},
+		{
+			name:     "one model",
+			models:   []Model{model("llama3.2:latest", "llama", 2000)},
+			want:     ModelStats{Count: 1, TotalSize: 2000, AverageSize: 2000, Families: map[string]int{"llama": 1}},
+			largest:  "llama3.2:latest",
+			families: []string{"llama"},
+		},
+		{
+			name: "families and other",
+			models: []Model{
+				model("llama3.2:latest", "llama", 2000),
+				model("qwen2.5:7b", "qwen2", 4700),
+				model("custom:latest", "", 100),
+				model("llama3.1:8b", "llama", 4900),
+				model("imported:latest", "", 200),
+				model("gemma3:4b", "gemma3", 3300),
+			},
+			want: ModelStats{
+				Count:       6,
+				TotalSize:   15200,
+				AverageSize: 2533,
+				Families:    map[string]int{"llama": 2, FamilyOther: 2, "qwen2": 1, "gemma3": 1},
+			},
+			largest:  "llama3.1:8b",
+			families: []string{"llama", FamilyOther, "gemma3", "qwen2"},
+		},
+		{
+			name: "tie for largest",
+			models: []Model{
+				model("small:latest", "llama", 10),
+				model("first:latest", "llama", 50),
+				model("second:latest", "qwen2", 50),
+			},
+			want:     ModelStats{Count: 3, TotalSize: 110, AverageSize: 36, Families: map[string]int{"llama": 2, "qwen2": 1}},
+			largest:  "first:latest",
+			families: []string{"llama", "qwen2"},
+		},
+	}
+	for _, tt := range tests {
+		got := Stats(tt.models)
+		var largest string
+		if got.Largest != nil {
+			largest = got.Largest.Name
+		}
+		if largest != tt.largest {
+			t.Errorf("%s: Largest = %q, want %q", tt.name, largest, tt.largest)
+		}
+		got.Largest = nil
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Stats = %+v, want %+v", tt.name, got, tt.want)
+		}
+		if names := got.FamilyNames(); !reflect.DeepEqual(names, tt.families) {
+			t.Errorf("%s: FamilyNames = %q, want %q", tt.name, names, tt.families)
+		}
+	}
+}
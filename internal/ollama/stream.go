@@ -0,0 +1,79 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// streamDecode reads newline-delimited JSON from r and calls fn with each
+// object. Blank lines are skipped and a final object without a trailing
+// newline is still delivered. An object carrying an "error" field ends the
+// stream with that error, as does cancellation of ctx or an error from fn.
+func streamDecode(ctx context.Context, r io.Reader, fn func(json.RawMessage) error) error {
+	br := bufio.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, readErr := br.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var status struct {
+				Error string `json:"error"`
+			}
+			if err := json.Unmarshal(line, &status); err != nil {
+				if readErr == io.EOF {
+					return fmt.Errorf("truncated stream: %w", err)
+				}
+				return fmt.Errorf("invalid stream line %q: %w", bodySnippet(bytes.NewReader(line)), err)
+			}
+			if status.Error != "" {
+				return errors.New(status.Error)
+			}
+			if err := fn(json.RawMessage(line)); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// postStream posts body as JSON to the endpoint p and passes every object of
+// the newline-delimited JSON response to fn. Errors are prefixed with op. If
+// the server sends nothing for longer than c.IdleTimeout, it fails with
+// ErrStreamStalled.
+func (c *Client) postStream(ctx context.Context, op, p string, body any, fn func(json.RawMessage) error) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ctx, idle, stop := c.watchIdle(ctx)
+	defer stop()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(p), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, idle.err(err))
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return newStatusError(op, res)
+	}
+	if err := streamDecode(ctx, idle.reader(res.Body), fn); err != nil {
+		return fmt.Errorf("%s: %w", op, idle.err(err))
+	}
+	return nil
+}
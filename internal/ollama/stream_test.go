@@ -0,0 +1,87 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestStreamDecode checks how streamDecode splits, skips and fails on the
+// shapes of newline-delimited JSON servers actually send.
+func TestStreamDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string // Objects delivered to fn, in order
+		wantErr string   // Substring of the expected error, "" for success
+	}{
+		{"empty", "", nil, ""},
+		{"objects", "{\"n\":1}\n{\"n\":2}\n", []string{`{"n":1}`, `{"n":2}`}, ""},
+		{"blank lines", "\n{\"n\":1}\n\n  \n{\"n\":2}\n\n", []string{`{"n":1}`, `{"n":2}`}, ""},
+		{"crlf", "{\"n\":1}\r\n{\"n\":2}\r\n", []string{`{"n":1}`, `{"n":2}`}, ""},
+		{"final line without newline", "{\"n\":1}\n{\"n\":2}", []string{`{"n":1}`, `{"n":2}`}, ""},
+		{"error mid-stream", "{\"n\":1}\n{\"error\":\"model not found\"}\n{\"n\":3}\n", []string{`{"n":1}`}, "model not found"},
+		{"error as final line", "{\"n\":1}\n{\"error\":\"out of memory\"}", []string{`{"n":1}`}, "out of memory"},
+		{"truncated final line", "{\"n\":1}\n{\"n\":", []string{`{"n":1}`}, "truncated stream"},
+		{"invalid line", "{\"n\":1}\nnot json\n{\"n\":3}\n", []string{`{"n":1}`}, `invalid stream line "not json"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := streamDecode(context.Background(), strings.NewReader(tt.input), func(m json.RawMessage) error {
+				got = append(got, string(m))
+				return nil
+			})
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("delivered %q, want %q", got, tt.want)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("error %v, want none", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestStreamDecodeStops checks that the stream ends, without delivering
+// further objects, when the context is canceled or fn fails.
+func TestStreamDecodeStops(t *testing.T) {
+	input := "{\"n\":1}\n{\"n\":2}\n{\"n\":3}\n"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	err := streamDecode(ctx, strings.NewReader(input), func(json.RawMessage) error {
+		calls++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("after cancel: %d objects, error %v; want 1 and context.Canceled", calls, err)
+	}
+
+	stop := errors.New("stop")
+	calls = 0
+	err = streamDecode(context.Background(), strings.NewReader(input), func(json.RawMessage) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("after fn failed: %d objects, error %v; want 1 and fn's error", calls, err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = streamDecode(canceled, strings.NewReader(input), func(json.RawMessage) error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Errorf("canceled before start: %d objects, error %v; want none and context.Canceled", calls, err)
+	}
+}
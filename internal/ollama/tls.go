@@ -0,0 +1,80 @@
+package ollama
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSOptions configures how the client verifies the server and
+// authenticates itself over HTTPS.
+type TLSOptions struct {
+	CAFile             string // PEM bundle of CAs trusted in addition to the system pool
+	CertFile           string // PEM client certificate presented to the server
+	KeyFile            string // PEM private key of the client certificate
+	InsecureSkipVerify bool   // Accept any server certificate; for testing only
+}
+
+// ConfigureTLS applies o to the client's transports, for the server and
+// for registries. It must be called before the client is used.
+func (c *Client) ConfigureTLS(o TLSOptions) error {
+	if c.transport == nil {
+		return errors.New("tls: client has no configurable transport")
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return err
+	}
+	c.transport.TLSClientConfig = cfg
+	if c.registryTransport != nil {
+		c.registryTransport.TLSClientConfig = cfg
+	}
+	return nil
+}
+
+// ConfigureTLS applies o to the library client's transport, so that a CA
+// bundle trusted for a TLS-intercepting proxy also covers ollama.com. It
+// must be called before the client is used.
+func (l *Library) ConfigureTLS(o TLSOptions) error {
+	if l.transport == nil {
+		return errors.New("tls: library client has no configurable transport")
+	}
+	cfg, err := o.config()
+	if err != nil {
+		return err
+	}
+	l.transport.TLSClientConfig = cfg
+	return nil
+}
+
+// config builds the TLS configuration described by o, loading its files.
+func (o TLSOptions) config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: reading CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,34 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tokenize splits text into the named model's tokens. It makes a POST
+// request to /api/tokenize, which only newer servers provide;
+// ErrNotSupported is returned when the endpoint is missing.
+func (c *Client) Tokenize(ctx context.Context, model, text string) ([]int, error) {
+	var out struct {
+		Tokens []int `json:"tokens"`
+	}
+	in := map[string]any{"model": model, "content": text}
+	if err := c.sendJSON(idempotent(ctx), http.MethodPost, "tokenize", "/api/tokenize", in, &out); err != nil {
+		return nil, err
+	}
+	return out.Tokens, nil
+}
+
+// Detokenize turns tokens of the named model back into text. It makes a
+// POST request to /api/detokenize, which only newer servers provide;
+// ErrNotSupported is returned when the endpoint is missing.
+func (c *Client) Detokenize(ctx context.Context, model string, tokens []int) (string, error) {
+	var out struct {
+		Content string `json:"content"`
+	}
+	in := map[string]any{"model": model, "tokens": tokens}
+	if err := c.sendJSON(idempotent(ctx), http.MethodPost, "detokenize", "/api/detokenize", in, &out); err != nil {
+		return "", err
+	}
+	return out.Content, nil
+}
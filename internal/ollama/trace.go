@@ -0,0 +1,97 @@
+package ollama
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceBodyLimit is the number of body bytes recorded per request or response.
+const traceBodyLimit = 2048
+
+// TraceTransport is an http.RoundTripper that logs the method, URL, status
+// and latency of every request, and optionally the leading bytes of request
+// and response bodies. Headers are never logged, so credentials stay out of
+// traces.
+type TraceTransport struct {
+	Next   http.RoundTripper // Transport doing the actual work; nil uses http.DefaultTransport
+	Log    *log.Logger       // Destination of the trace lines
+	Bodies bool              // Whether to log request and response bodies
+}
+
+// RoundTrip sends req through Next and logs the exchange.
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if t.Bodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, traceBodyLimit))
+			body.Close()
+			t.Log.Printf("→ %s %s body=%s", req.Method, req.URL.Redacted(), traceText(data))
+		}
+	}
+	start := time.Now()
+	res, err := next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.Log.Printf("%s %s error=%v latency=%s", req.Method, req.URL.Redacted(), err, latency)
+		return nil, err
+	}
+	t.Log.Printf("%s %s status=%d latency=%s", req.Method, req.URL.Redacted(), res.StatusCode, latency)
+	if t.Bodies {
+		res.Body = &traceBody{ReadCloser: res.Body, log: t.Log, prefix: "← " + req.Method + " " + req.URL.Redacted()}
+	}
+	return res, nil
+}
+
+// traceBody records the leading bytes of a response body as it is read and
+// logs them when the body is closed, so streamed responses are not buffered.
+type traceBody struct {
+	io.ReadCloser
+	log    *log.Logger
+	prefix string
+	buf    bytes.Buffer
+	once   sync.Once
+}
+
+// Read reads from the body, keeping up to traceBodyLimit bytes for the trace.
+func (b *traceBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := traceBodyLimit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+// Close closes the body and logs what was read of it.
+func (b *traceBody) Close() error {
+	b.once.Do(func() {
+		b.log.Printf("%s body=%s", b.prefix, traceText(b.buf.Bytes()))
+	})
+	return b.ReadCloser.Close()
+}
+
+// traceText formats body bytes for a single trace line.
+func traceText(data []byte) string {
+	s := strings.ReplaceAll(string(data), "\n", `\n`)
+	if len(data) >= traceBodyLimit {
+		s += "…"
+	}
+	return s
+}
+
+// EnableTrace logs every request the client makes to w. With bodies set,
+// the leading bytes of request and response bodies are logged as well.
+func (c *Client) EnableTrace(w io.Writer, bodies bool) {
+	logger := log.New(w, "", log.LstdFlags|log.Lmicroseconds)
+	c.HTTP.Transport = &TraceTransport{Next: c.HTTP.Transport, Log: logger, Bodies: bodies}
+	if c.registry != nil {
+		c.registry.Transport = &TraceTransport{Next: c.registry.Transport, Log: logger, Bodies: bodies}
+	}
+}
@@ -0,0 +1,31 @@
+package ollama
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// unixBase is the base of request URLs sent over a Unix domain socket; the
+// host is ignored since the transport always dials the socket.
+const unixBase = "http://unix"
+
+// socketPath returns the socket path of a base URL such as
+// "unix:///var/run/ollama.sock".
+func socketPath(base string) (string, bool) {
+	p, ok := strings.CutPrefix(base, "unix://")
+	return p, ok && p != ""
+}
+
+// unixTransport returns an HTTP transport that sends every request over the
+// Unix domain socket at path.
+func unixTransport(path string) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", path)
+	}
+	return t
+}
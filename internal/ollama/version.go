@@ -0,0 +1,119 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Version retrieves the version of the Ollama server.
+// It makes a GET request to /api/version.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint("/api/version"), nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", newStatusError("version", res)
+	}
+	var payload struct {
+		Version string `json:"version"`
+	}
+	if err := decodeJSON(res, &payload); err != nil {
+		return "", err
+	}
+	return payload.Version, nil
+}
+
+// Feature identifies a server capability that requires a minimum version.
+type Feature int
+
+const (
+	FeatureRunningDetails   Feature = iota // size_vram and expires_at in /api/ps
+	FeatureOpenAI                          // OpenAI-compatible /v1 endpoints
+	FeatureTools                           // Tool calling in /api/chat
+	FeatureStructuredOutput                // JSON schema in the format field
+	FeatureStructuredCreate                // Structured fields instead of a Modelfile in /api/create
+)
+
+// featureVersions maps each feature to the first server version supporting it.
+var featureVersions = map[Feature]string{
+	FeatureRunningDetails:   "0.1.38",
+	FeatureOpenAI:           "0.1.24",
+	FeatureTools:            "0.3.0",
+	FeatureStructuredOutput: "0.5.0",
+	FeatureStructuredCreate: "0.5.5",
+}
+
+// String returns a human-readable name for the feature.
+func (f Feature) String() string {
+	switch f {
+	case FeatureRunningDetails:
+		return "running model details"
+	case FeatureOpenAI:
+		return "OpenAI-compatible API"
+	case FeatureTools:
+		return "tool calling"
+	case FeatureStructuredOutput:
+		return "structured output"
+	case FeatureStructuredCreate:
+		return "structured model creation"
+	default:
+		return "feature " + strconv.Itoa(int(f))
+	}
+}
+
+// MinVersion returns the first server version supporting f.
+func (f Feature) MinVersion() string {
+	return featureVersions[f]
+}
+
+// SupportsFeature reports whether a server of the given version supports f.
+// Unparseable versions and development builds (0.0.0) are assumed to support
+// everything, so that gating never hides features on unusual builds.
+func SupportsFeature(version string, f Feature) bool {
+	minVersion, ok := featureVersions[f]
+	if !ok {
+		return true
+	}
+	v, ok := parseVersion(version)
+	if !ok || v == [3]int{} {
+		return true
+	}
+	m, _ := parseVersion(minVersion)
+	for i := range v {
+		if v[i] != m[i] {
+			return v[i] > m[i]
+		}
+	}
+	return true
+}
+
+// parseVersion parses "major.minor.patch" with an optional "v" prefix and
+// ignores any pre-release or build suffix ("0.5.0-rc1", "0.3.2+abc").
+// A pre-release is treated as its release, which is what feature gating needs.
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 || parts[0] == "" {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
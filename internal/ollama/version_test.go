@@ -0,0 +1,70 @@
+package ollama
+
+import "testing"
+
+// TestParseVersion checks the versions a server may report, including
+// prefixes, suffixes and malformed strings.
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   [3]int
+		wantOK bool
+	}{
+		{"0.1.32", [3]int{0, 1, 32}, true},
+		{"v0.5.1", [3]int{0, 5, 1}, true},
+		{"0.6.0-rc1", [3]int{0, 6, 0}, true},
+		{"0.3.2+abc", [3]int{0, 3, 2}, true},
+		{" 0.4.0 ", [3]int{0, 4, 0}, true},
+		{"1.2", [3]int{1, 2, 0}, true},
+		{"0.0.0", [3]int{}, true},
+		{"", [3]int{}, false},
+		{"v", [3]int{}, false},
+		{"garbage", [3]int{}, false},
+		{"0.x.1", [3]int{}, false},
+		{"0..1", [3]int{}, false},
+		{"0.1.2.3", [3]int{}, false},
+		{"-1.0.0", [3]int{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseVersion(tt.in)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("parseVersion(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestSupportsFeature checks feature gating at each feature's minimum
+// version and either side of it, and that unknown versions are allowed.
+func TestSupportsFeature(t *testing.T) {
+	tests := []struct {
+		version string
+		feature Feature
+		want    bool
+	}{
+		{"0.1.37", FeatureRunningDetails, false},
+		{"0.1.38", FeatureRunningDetails, true},
+		{"0.1.39", FeatureRunningDetails, true},
+		{"0.1.23", FeatureOpenAI, false},
+		{"0.1.24", FeatureOpenAI, true},
+		{"0.2.9", FeatureTools, false},
+		{"0.3.0", FeatureTools, true},
+		{"0.3.0-rc1", FeatureTools, true},
+		{"0.4.7", FeatureStructuredOutput, false},
+		{"0.5.0", FeatureStructuredOutput, true},
+		{"v0.5.1", FeatureStructuredOutput, true},
+		{"0.5.4", FeatureStructuredCreate, false},
+		{"0.5.5", FeatureStructuredCreate, true},
+		{"1.0.0", FeatureStructuredCreate, true},
+		{"0.1.32", FeatureTools, false},
+		{"0.6.0-rc1", FeatureStructuredCreate, true},
+		{"0.0.0", FeatureStructuredCreate, true},
+		{"", FeatureStructuredCreate, true},
+		{"garbage", FeatureTools, true},
+		{"0.1.0", Feature(-1), true},
+	}
+	for _, tt := range tests {
+		if got := SupportsFeature(tt.version, tt.feature); got != tt.want {
+			t.Errorf("SupportsFeature(%q, %v) = %v, want %v", tt.version, tt.feature, got, tt.want)
+		}
+	}
+}
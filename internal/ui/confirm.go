@@ -0,0 +1,123 @@
+// Package ui provides reusable gocui widgets shared by the application's
+// views.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// Confirm is a modal yes/no dialog. At most one question is open at a time;
+// it is answered from the keyboard, and anything but an explicit yes
+// declines, so destructive actions are never taken by accident.
+type Confirm struct {
+	name    string                 // Name of the dialog view
+	message string                 // Question shown to the user, may span lines
+	onYes   func(*gocui.Gui) error // Action to run on confirmation, nil when closed
+	prev    string                 // View focused before the dialog opened
+}
+
+// NewConfirm returns a closed confirmation dialog drawn in the view with
+// the given name.
+func NewConfirm(name string) *Confirm {
+	return &Confirm{name: name}
+}
+
+// Ask opens the dialog with message. onYes runs on the GUI goroutine if the
+// user confirms; focus returns to fallback afterwards when no view was
+// focused. A question already open is replaced.
+func (c *Confirm) Ask(g *gocui.Gui, message, fallback string, onYes func(*gocui.Gui) error) {
+	switch v := g.CurrentView(); {
+	case v != nil && v.Name() != c.name:
+		c.prev = v.Name()
+	case !c.Open():
+		c.prev = fallback
+	}
+	// Layout draws the message when it creates the view, so drop any view
+	// still showing an earlier question.
+	g.DeleteView(c.name)
+	c.message, c.onYes = message, onYes
+}
+
+// Open reports whether a question is waiting for an answer.
+func (c *Confirm) Open() bool {
+	return c.onYes != nil
+}
+
+// Layout draws the dialog, if it is open, centered on screen, and removes it
+// once it has been answered. Multi-line messages, such as lists of affected
+// models, get one row per line as far as the screen allows.
+func (c *Confirm) Layout(g *gocui.Gui) error {
+	if !c.Open() {
+		if _, err := g.View(c.name); err == nil {
+			return g.DeleteView(c.name)
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	lines := strings.Split(c.message, "\n")
+	w := 30
+	for _, line := range lines {
+		if len(line)+4 > w {
+			w = len(line) + 4
+		}
+	}
+	if w > maxX-2 {
+		w = maxX - 2
+	}
+	h := len(lines) + 2
+	if h > maxY-2 {
+		h = maxY - 2
+	}
+	x0, y0 := (maxX-w)/2, (maxY-h)/2
+	v, err := g.SetView(c.name, x0, y0, x0+w, y0+h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Confirm"
+		v.Wrap = true
+		fmt.Fprintln(v, c.message)
+		fmt.Fprint(v, "[y]es / [N]o")
+		if _, err := g.SetCurrentView(c.name); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetViewOnTop(c.name)
+	return err
+}
+
+// close removes the dialog, restores focus to the previous view and returns
+// the action the question guarded.
+func (c *Confirm) close(g *gocui.Gui) (func(*gocui.Gui) error, error) {
+	onYes := c.onYes
+	c.onYes = nil
+	if err := g.DeleteView(c.name); err != nil && err != gocui.ErrUnknownView {
+		return onYes, err
+	}
+	if _, err := g.SetCurrentView(c.prev); err != nil && err != gocui.ErrUnknownView {
+		return onYes, err
+	}
+	return onYes, nil
+}
+
+// Yes closes the dialog and runs its action. It is meant to be bound to the
+// dialog's confirming key.
+func (c *Confirm) Yes(g *gocui.Gui, _ *gocui.View) error {
+	onYes, err := c.close(g)
+	if err != nil || onYes == nil {
+		return err
+	}
+	return onYes(g)
+}
+
+// No closes the dialog without running its action. It is meant to be bound
+// to every other key the dialog handles, including Enter, so that No is the
+// default answer.
+func (c *Confirm) No(g *gocui.Gui, _ *gocui.View) error {
+	_, err := c.close(g)
+	return err
+}
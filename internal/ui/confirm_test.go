@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/jroimartin/gocui"
+)
+
+// TestConfirm checks that only Yes runs the action, that a new question
+// replaces an open one, and that focus returns to the view focused before
+// the dialog opened, or to the fallback if there was none.
+func TestConfirm(t *testing.T) {
+	g := &gocui.Gui{}
+	for _, name := range []string{"list", "fallback"} {
+		if _, err := g.SetView(name, 0, 0, 40, 10); err != gocui.ErrUnknownView {
+			t.Fatalf("SetView: %v", err)
+		}
+	}
+	var ran []string
+	action := func(name string) func(*gocui.Gui) error {
+		return func(*gocui.Gui) error {
+			ran = append(ran, name)
+			return nil
+		}
+	}
+	c := NewConfirm("confirm")
+
+	g.SetCurrentView("list")
+	c.Ask(g, "Delete llama3.2?", "fallback", action("delete"))
+	if !c.Open() {
+		t.Fatal("dialog not open after Ask")
+	}
+	if err := c.No(g, nil); err != nil {
+		t.Fatalf("No: %v", err)
+	}
+	if c.Open() || len(ran) != 0 {
+		t.Errorf("open=%v ran=%q after No, want closed without running", c.Open(), ran)
+	}
+	if cur := g.CurrentView(); cur == nil || cur.Name() != "list" {
+		t.Errorf("focus did not return to the list after No")
+	}
+
+	c.Ask(g, "Delete llama3.2?", "fallback", action("delete"))
+	c.Ask(g, "Unload qwen2.5?", "fallback", action("unload"))
+	if err := c.Yes(g, nil); err != nil {
+		t.Fatalf("Yes: %v", err)
+	}
+	if len(ran) != 1 || ran[0] != "unload" {
+		t.Errorf("ran %q, want only the replacing question's action", ran)
+	}
+	if err := c.Yes(g, nil); err != nil || len(ran) != 1 {
+		t.Errorf("Yes on a closed dialog: err=%v ran=%q, want nothing run", err, ran)
+	}
+
+	g = &gocui.Gui{}
+	g.SetView("fallback", 0, 0, 40, 10)
+	c = NewConfirm("confirm")
+	c.Ask(g, "Delete llama3.2?", "fallback", action("delete"))
+	if err := c.No(g, nil); err != nil {
+		t.Fatalf("No: %v", err)
+	}
+	if cur := g.CurrentView(); cur == nil || cur.Name() != "fallback" {
+		t.Errorf("focus did not go to the fallback with no view focused before")
+	}
+}
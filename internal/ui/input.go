@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jroimartin/gocui"
+)
+
+// maxHistory is the number of entries an Input remembers.
+const maxHistory = 50
+
+// Input is a single-line text input. It remembers submitted values, which
+// ↑/↓ (or Ctrl+P/Ctrl+N) recall, and supports the usual line-editing keys:
+// Home/Ctrl+A, End/Ctrl+E, Ctrl+U, Ctrl+K and Ctrl+W. An Input is also the
+// gocui.Editor of its view.
+type Input struct {
+	name     string                         // Name of the input view
+	focus    func(*gocui.Gui, string) error // Focuses the input view once created
+	title    string                         // Frame title, including key hints
+	value    string                         // Initial text
+	onSubmit func(*gocui.Gui, string) error // Action to run with the entered text, nil when closed
+	onChange func(string)                   // Called with the text after each edit, may be nil
+	prev     string                         // View focused before the input opened
+
+	history []string // Submitted values, oldest first
+	recall  int      // Index into history being shown, len(history) for the draft
+	draft   string   // Text typed before browsing the history
+}
+
+// NewInput returns a closed input drawn in the view with the given name.
+// focus is called to focus the view when it is created; nil means
+// gocui.Gui.SetCurrentView.
+func NewInput(name string, focus func(*gocui.Gui, string) error) *Input {
+	if focus == nil {
+		focus = func(g *gocui.Gui, name string) error {
+			_, err := g.SetCurrentView(name)
+			return err
+		}
+	}
+	return &Input{name: name, focus: focus}
+}
+
+// Ask opens the input with the given title and initial value. onSubmit runs
+// on the GUI goroutine with the trimmed text when the input is submitted;
+// focus returns to fallback afterwards when no view was focused.
+func (in *Input) Ask(g *gocui.Gui, title, value, fallback string, onSubmit func(*gocui.Gui, string) error) {
+	switch v := g.CurrentView(); {
+	case v != nil && v.Name() != in.name:
+		in.prev = v.Name()
+	case !in.Open():
+		in.prev = fallback
+	}
+	// Layout writes the value when it creates the view, so drop any view
+	// still showing an earlier question.
+	g.DeleteView(in.name)
+	in.title, in.value, in.onSubmit, in.onChange = title, value, onSubmit, nil
+	in.recall, in.draft = len(in.history), ""
+}
+
+// OnChange sets a function called with the text after every edit, for
+// inputs that act while typing. Ask clears it.
+func (in *Input) OnChange(fn func(string)) {
+	in.onChange = fn
+}
+
+// Open reports whether the input is waiting for text.
+func (in *Input) Open() bool {
+	return in.onSubmit != nil
+}
+
+// Layout draws the input, if it is open, as a one-line box from (x0, y0) to
+// x1, and removes it once it has been closed.
+func (in *Input) Layout(g *gocui.Gui, x0, y0, x1 int) error {
+	if !in.Open() {
+		if _, err := g.View(in.name); err == nil {
+			return g.DeleteView(in.name)
+		}
+		return nil
+	}
+
+	v, err := g.SetView(in.name, x0, y0, x1, y0+2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = in.title
+		v.Editable = true
+		v.Editor = in
+		fmt.Fprint(v, in.value)
+		v.SetCursor(len([]rune(in.value)), 0)
+		if err := in.focus(g, in.name); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetViewOnTop(in.name)
+	return err
+}
+
+// text returns the text currently in v, untrimmed.
+func text(v *gocui.View) string {
+	return strings.ReplaceAll(v.Buffer(), "\n", "")
+}
+
+// position returns the cursor's index into the text of v.
+func position(v *gocui.View) int {
+	ox, _ := v.Origin()
+	cx, _ := v.Cursor()
+	return ox + cx
+}
+
+// setText replaces the text of v and places the cursor at rune index pos,
+// scrolling horizontally as needed to keep it visible.
+func setText(v *gocui.View, s string, pos int) {
+	v.Clear()
+	fmt.Fprint(v, s)
+	w, _ := v.Size()
+	ox := 0
+	if w > 0 && pos >= w {
+		ox = pos - w + 1
+	}
+	v.SetOrigin(ox, 0)
+	v.SetCursor(pos-ox, 0)
+}
+
+// Edit handles a key typed into the input: history recall and line-editing
+// keys are handled here, everything else by gocui.DefaultEditor.
+func (in *Input) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	before := text(v)
+	runes := []rune(before)
+	pos := position(v)
+	if pos > len(runes) {
+		pos = len(runes)
+	}
+	switch {
+	case key == gocui.KeyArrowUp || key == gocui.KeyCtrlP:
+		in.browse(v, -1)
+	case key == gocui.KeyArrowDown || key == gocui.KeyCtrlN:
+		in.browse(v, 1)
+	case key == gocui.KeyHome || key == gocui.KeyCtrlA:
+		setText(v, before, 0)
+	case key == gocui.KeyEnd || key == gocui.KeyCtrlE:
+		setText(v, before, len(runes))
+	case key == gocui.KeyCtrlU:
+		setText(v, string(runes[pos:]), 0)
+	case key == gocui.KeyCtrlK:
+		setText(v, string(runes[:pos]), pos)
+	case key == gocui.KeyCtrlW:
+		start := pos
+		for start > 0 && unicode.IsSpace(runes[start-1]) {
+			start--
+		}
+		for start > 0 && !unicode.IsSpace(runes[start-1]) {
+			start--
+		}
+		setText(v, string(runes[:start])+string(runes[pos:]), start)
+	default:
+		gocui.DefaultEditor.Edit(v, key, ch, mod)
+	}
+	if after := text(v); after != before && in.onChange != nil {
+		in.onChange(strings.TrimSpace(after))
+	}
+}
+
+// browse replaces the text with the history entry dir steps away from the
+// one shown, keeping what was typed as a draft to return to.
+func (in *Input) browse(v *gocui.View, dir int) {
+	next := in.recall + dir
+	if next < 0 || next > len(in.history) {
+		return
+	}
+	if in.recall == len(in.history) {
+		in.draft = text(v)
+	}
+	in.recall = next
+	s := in.draft
+	if next < len(in.history) {
+		s = in.history[next]
+	}
+	setText(v, s, len([]rune(s)))
+}
+
+// remember adds s to the history unless it is empty or repeats the latest
+// entry.
+func (in *Input) remember(s string) {
+	if s == "" || (len(in.history) > 0 && in.history[len(in.history)-1] == s) {
+		return
+	}
+	in.history = append(in.history, s)
+	if len(in.history) > maxHistory {
+		in.history = in.history[len(in.history)-maxHistory:]
+	}
+}
+
+// close removes the input, restores focus to the previous view and returns
+// the submit action.
+func (in *Input) close(g *gocui.Gui) (func(*gocui.Gui, string) error, error) {
+	onSubmit := in.onSubmit
+	in.onSubmit, in.onChange = nil, nil
+	if err := g.DeleteView(in.name); err != nil && err != gocui.ErrUnknownView {
+		return onSubmit, err
+	}
+	if _, err := g.SetCurrentView(in.prev); err != nil && err != gocui.ErrUnknownView {
+		return onSubmit, err
+	}
+	return onSubmit, nil
+}
+
+// Submit closes the input, remembers its text and runs the submit action
+// with it. It is meant to be bound to Enter in the input view.
+func (in *Input) Submit(g *gocui.Gui, v *gocui.View) error {
+	s := strings.TrimSpace(text(v))
+	onSubmit, err := in.close(g)
+	if err != nil || onSubmit == nil {
+		return err
+	}
+	in.remember(s)
+	return onSubmit(g, s)
+}
+
+// Cancel closes the input without running the submit action.
+func (in *Input) Cancel(g *gocui.Gui, _ *gocui.View) error {
+	_, err := in.close(g)
+	return err
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jroimartin/gocui"
+)
+
+// inputTest drives an Input in a GUI that is never drawn to a terminal.
+type inputTest struct {
+	t         *testing.T
+	g         *gocui.Gui
+	in        *Input
+	submitted []string
+}
+
+// newInputTest returns an input test with a "list" view focused.
+func newInputTest(t *testing.T) *inputTest {
+	g := &gocui.Gui{}
+	if _, err := g.SetView("list", 0, 0, 40, 10); err != gocui.ErrUnknownView {
+		t.Fatalf("SetView: %v", err)
+	}
+	g.SetCurrentView("list")
+	return &inputTest{t: t, g: g, in: NewInput("input", nil)}
+}
+
+// open opens the input with an empty value and returns its view.
+func (it *inputTest) open() *gocui.View {
+	it.t.Helper()
+	it.in.Ask(it.g, "Filter", "", "list", func(_ *gocui.Gui, s string) error {
+		it.submitted = append(it.submitted, s)
+		return nil
+	})
+	if err := it.in.Layout(it.g, 0, 0, 40); err != nil {
+		it.t.Fatalf("Layout: %v", err)
+	}
+	v, err := it.g.View("input")
+	if err != nil {
+		it.t.Fatalf("input view: %v", err)
+	}
+	return v
+}
+
+// typeText types s into v one rune at a time.
+func (it *inputTest) typeText(v *gocui.View, s string) {
+	for _, r := range s {
+		it.in.Edit(v, 0, r, gocui.ModNone)
+	}
+}
+
+// submit opens the input, types s and submits it.
+func (it *inputTest) submit(s string) {
+	it.t.Helper()
+	v := it.open()
+	it.typeText(v, s)
+	if err := it.in.Submit(it.g, v); err != nil {
+		it.t.Fatalf("Submit: %v", err)
+	}
+}
+
+// press sends key to v and checks the resulting text.
+func (it *inputTest) press(v *gocui.View, key gocui.Key, want string) {
+	it.t.Helper()
+	it.in.Edit(v, key, 0, gocui.ModNone)
+	if got := text(v); got != want {
+		it.t.Errorf("text after key %#x = %q, want %q", key, got, want)
+	}
+}
+
+// TestInputHistoryBrowse checks that ↑ and ↓ step through the history,
+// stop at either end, and return to the text typed before browsing.
+func TestInputHistoryBrowse(t *testing.T) {
+	it := newInputTest(t)
+	for _, s := range []string{"llama", "qwen", "gemma"} {
+		it.submit(s)
+	}
+
+	v := it.open()
+	it.typeText(v, "dra")
+	it.press(v, gocui.KeyArrowUp, "gemma")
+	it.press(v, gocui.KeyCtrlP, "qwen")
+	it.press(v, gocui.KeyArrowUp, "llama")
+	it.press(v, gocui.KeyArrowUp, "llama")
+	it.press(v, gocui.KeyArrowDown, "qwen")
+	it.press(v, gocui.KeyCtrlN, "gemma")
+	it.press(v, gocui.KeyArrowDown, "dra")
+	it.press(v, gocui.KeyArrowDown, "dra")
+	if cx, _ := v.Cursor(); cx != 3 {
+		t.Errorf("cursor at %d after returning to the draft, want the end", cx)
+	}
+}
+
+// TestInputHistoryEditRecalled checks that editing a recalled entry and
+// submitting it adds the edited text without changing the original entry.
+func TestInputHistoryEditRecalled(t *testing.T) {
+	it := newInputTest(t)
+	it.submit("llama")
+	it.submit("qwen")
+
+	v := it.open()
+	it.press(v, gocui.KeyArrowUp, "qwen")
+	it.typeText(v, "2.5")
+	if err := it.in.Submit(it.g, v); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if last := it.submitted[len(it.submitted)-1]; last != "qwen2.5" {
+		t.Errorf("submitted %q, want the edited entry", last)
+	}
+
+	v = it.open()
+	it.press(v, gocui.KeyArrowUp, "qwen2.5")
+	it.press(v, gocui.KeyArrowUp, "qwen")
+	it.press(v, gocui.KeyArrowUp, "llama")
+}
+
+// TestInputHistoryDedupe checks that blank text and a repeat of the latest
+// entry are not remembered, that entries are trimmed, and that the history
+// is capped.
+func TestInputHistoryDedupe(t *testing.T) {
+	it := newInputTest(t)
+	for _, s := range []string{"llama", "llama", "  ", " qwen ", "llama"} {
+		it.submit(s)
+	}
+	if want := []string{"llama", "qwen", "llama"}; !slices.Equal(it.in.history, want) {
+		t.Errorf("history = %q, want %q", it.in.history, want)
+	}
+	if want := []string{"llama", "llama", "", "qwen", "llama"}; !slices.Equal(it.submitted, want) {
+		t.Errorf("submitted %q, want %q", it.submitted, want)
+	}
+
+	for i := 0; i < maxHistory+5; i++ {
+		it.in.remember(string(rune('a' + i%26)))
+	}
+	if len(it.in.history) != maxHistory {
+		t.Errorf("history holds %d entries, want %d", len(it.in.history), maxHistory)
+	}
+}
+
+// TestInputCancel checks that canceling neither submits nor remembers the
+// text, and that focus returns to the view focused before.
+func TestInputCancel(t *testing.T) {
+	it := newInputTest(t)
+	v := it.open()
+	if cur := it.g.CurrentView(); cur == nil || cur.Name() != "input" {
+		t.Fatalf("input not focused once drawn")
+	}
+	it.typeText(v, "llama")
+	if err := it.in.Cancel(it.g, v); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if it.in.Open() || len(it.submitted) != 0 || len(it.in.history) != 0 {
+		t.Errorf("open=%v submitted=%q history=%q after cancel", it.in.Open(), it.submitted, it.in.history)
+	}
+	if cur := it.g.CurrentView(); cur == nil || cur.Name() != "list" {
+		t.Errorf("focus did not return to the list")
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// keyAliases names keys accepted in the keybindings config in addition to
+// the names keyName shows.
+var keyAliases = map[string]gocui.Key{
+	"up":        gocui.KeyArrowUp,
+	"down":      gocui.KeyArrowDown,
+	"left":      gocui.KeyArrowLeft,
+	"right":     gocui.KeyArrowRight,
+	"escape":    gocui.KeyEsc,
+	"return":    gocui.KeyEnter,
+	"pageup":    gocui.KeyPgup,
+	"pagedown":  gocui.KeyPgdn,
+	"del":       gocui.KeyDelete,
+	"backspace": gocui.KeyBackspace2,
+}
+
+// parseKey parses a key as written in the keybindings config: a single
+// character, a key name such as "Enter", "F5" or "PgUp", or "Ctrl+" and a
+// letter. Names are not case-sensitive, unlike single characters.
+func parseKey(name string) (any, error) {
+	if r := []rune(name); len(r) == 1 {
+		return r[0], nil
+	}
+	lower := strings.ToLower(name)
+	if letter, ok := strings.CutPrefix(lower, "ctrl+"); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return gocui.KeyCtrlA + gocui.Key(letter[0]-'a'), nil
+	}
+	if k, ok := keyAliases[lower]; ok {
+		return k, nil
+	}
+	for k, n := range specialKeyNames {
+		if strings.ToLower(n) == lower && !isMouseKey(k) {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %q", name)
+}
+
+// isMouseKey reports whether k is a mouse event rather than a key.
+func isMouseKey(k gocui.Key) bool {
+	return k == gocui.MouseLeft || k == gocui.MouseWheelUp || k == gocui.MouseWheelDown
+}
+
+// parseKeys parses the space-separated keys configured for an action.
+func parseKeys(s string) ([]any, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no key given")
+	}
+	keys := make([]any, 0, len(fields))
+	for _, f := range fields {
+		k, err := parseKey(f)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// validateKeybindings checks that every action in the keybindings config is
+// given keys that can be parsed. Whether the actions exist is only known
+// once the binding table is built.
+func validateKeybindings(m map[string]string) error {
+	for action, keys := range m {
+		if _, err := parseKeys(keys); err != nil {
+			return fmt.Errorf("keybindings: %s: %w", action, err)
+		}
+	}
+	return nil
+}
+
+// actionName normalizes an action name from the keybindings config to the
+// description it refers to, so "load_with_keep_alive" matches "load with
+// keep alive".
+func actionName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", " "))
+}
+
+// customBindings returns table with the keys of the actions in custom
+// replaced. Actions are named by their description; an action bound in
+// several views gets the new keys in each of them. It reports unknown
+// actions, keys that cannot be parsed and conflicts the new keys cause.
+func customBindings(table []binding, custom map[string]string) ([]binding, error) {
+	if len(custom) == 0 {
+		return table, nil
+	}
+	keys := make(map[string][]any, len(custom))
+	for action, s := range custom {
+		k, err := parseKeys(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", action, err)
+		}
+		keys[actionName(action)] = k
+	}
+
+	var unknown []string
+	for action := range custom {
+		if !slices.ContainsFunc(table, func(b binding) bool { return b.desc == actionName(action) }) {
+			unknown = append(unknown, fmt.Sprintf("%q", action))
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("unknown actions %s (see ? for the list)", strings.Join(unknown, ", "))
+	}
+
+	// Each rebound action keeps its place in the table, so the legend and
+	// help list it where they did, with one entry per configured key.
+	type scopedAction struct{ view, desc string }
+	done := make(map[scopedAction]bool)
+	out := make([]binding, 0, len(table))
+	for _, b := range table {
+		k, ok := keys[b.desc]
+		if !ok {
+			out = append(out, b)
+			continue
+		}
+		if done[scopedAction{b.view, b.desc}] {
+			continue
+		}
+		done[scopedAction{b.view, b.desc}] = true
+		for _, key := range k {
+			out = append(out, binding{b.view, key, gocui.ModNone, b.handler, b.desc})
+		}
+	}
+	if err := validateBindings(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
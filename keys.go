@@ -0,0 +1,422 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// binding is a single entry in the key binding table.
+// An empty view makes the binding global, i.e. active in every view.
+type binding struct {
+	view    string                              // View the binding is scoped to, "" for global
+	key     any                                 // gocui.Key or rune
+	mod     gocui.Modifier                      // Key modifier
+	handler func(*gocui.Gui, *gocui.View) error // Action to run
+	desc    string                              // Short description of the action
+}
+
+// bindings returns the application's key binding table, adjusted for the
+// configured keymap. Debug bindings are included only when running with
+// --debug.
+func (a *App) bindings() []binding {
+	table := []binding{
+		{"", gocui.KeyCtrlC, gocui.ModNone, a.onQuit, "quit"},
+		{"", 'q', gocui.ModNone, a.onQuit, "quit"},
+		{"", 'r', gocui.ModNone, a.onRefresh, "refresh"},
+		{"", gocui.KeyCtrlR, gocui.ModNone, a.onRefresh, "refresh"},
+		{"", 'I', gocui.ModNone, a.onToggleAutoRefresh, "pause/resume auto-refresh"},
+		{"", 'v', gocui.ModNone, a.onToggleRunningDetail, "detailed running rows"},
+		{"", 'P', gocui.ModNone, a.onPreloadDefault, "load default model"},
+		{"", 'C', gocui.ModNone, a.onCopyStatus, "copy status"},
+		{"", 'L', gocui.ModNone, a.onShowLogs, "server log"},
+		{"", gocui.KeyCtrlL, gocui.ModNone, a.onReloadConfig, "reload config"},
+		{"", 'S', gocui.ModNone, a.onShowStats, "statistics"},
+		{"", 'E', gocui.ModNone, a.onShowLastError, "last error"},
+		{"", 'x', gocui.ModNone, a.onCancelOp, "cancel operation or selected task"},
+		{"", '?', gocui.ModNone, a.onShowHelp, "help"},
+		{"", ':', gocui.ModNone, a.onOpenPalette, "command palette"},
+		{"", 'A', gocui.ModNone, a.onToggleTasks, "expand tasks"},
+		{"", '[', gocui.ModNone, a.onShrinkSplit, "narrow installed pane"},
+		{"", ']', gocui.ModNone, a.onGrowSplit, "widen installed pane"},
+		{"", 'H', gocui.ModNone, a.onCycleRunningPane, "show/auto-hide/hide running pane"},
+		{"", 'z', gocui.ModNone, a.onToggleZoom, "zoom focused pane"},
+		{"", 'M', gocui.ModNone, a.onShowStatusLog, "status log"},
+
+		{"", gocui.KeyTab, gocui.ModNone, a.onFocusNext, "switch pane"},
+
+		{viewInstalled, gocui.KeyArrowUp, gocui.ModNone, a.onCursorUp, "move up"},
+		{viewInstalled, gocui.KeyArrowDown, gocui.ModNone, a.onCursorDown, "move down"},
+		{viewInstalled, 'k', gocui.ModNone, a.onCursorUp, "move up"},
+		{viewInstalled, 'j', gocui.ModNone, a.onCursorDown, "move down"},
+		{viewInstalled, gocui.KeyPgup, gocui.ModNone, pager(a.moveInstalled, -1, false), "page up"},
+		{viewInstalled, gocui.KeyPgdn, gocui.ModNone, pager(a.moveInstalled, 1, false), "page down"},
+		{viewInstalled, gocui.KeyCtrlU, gocui.ModNone, pager(a.moveInstalled, -1, true), "half page up"},
+		{viewInstalled, gocui.KeyCtrlD, gocui.ModNone, pager(a.moveInstalled, 1, true), "half page down"},
+		{viewInstalled, gocui.KeyEnter, gocui.ModNone, a.onShowDetails, "details"},
+		{viewInstalled, 'w', gocui.ModNone, a.onPreload, "load"},
+		{viewInstalled, 'W', gocui.ModNone, a.onPreloadWith, "load with keep alive"},
+		{viewInstalled, 'p', gocui.ModNone, a.onPull, "pull"},
+		{viewInstalled, '+', gocui.ModNone, a.onPullByName, "pull by name"},
+		{viewInstalled, 'd', gocui.ModNone, a.onDelete, "delete"},
+		{viewInstalled, '>', gocui.ModNone, a.onPush, "push"},
+		{viewInstalled, 'c', gocui.ModNone, a.onCopyModel, "copy to new name"},
+		{viewInstalled, 'y', gocui.ModNone, a.onYankName, "yank name"},
+		{viewInstalled, 'Y', gocui.ModNone, a.onYankDigest, "yank digest"},
+		{viewInstalled, 'o', gocui.ModNone, a.onOpenModelPage, "open ollama.com page"},
+		{viewInstalled, 'e', gocui.ModNone, a.onEmbed, "embed text"},
+		{viewInstalled, 'T', gocui.ModNone, a.onCountTokens, "count tokens"},
+		{viewInstalled, 'i', gocui.ModNone, a.onOpenChat, "chat"},
+		{viewInstalled, 'B', gocui.ModNone, a.onToggleSize, "toggle size"},
+		{viewInstalled, 'g', gocui.ModNone, a.onToggleGroupByFamily, "group by family"},
+		{viewInstalled, 'R', gocui.ModNone, a.onToggleRunningFirst, "running first"},
+		{viewInstalled, 's', gocui.ModNone, a.onCycleSort, "cycle sort"},
+		{viewInstalled, 'U', gocui.ModNone, a.onCheckUpdates, "check updates"},
+		{viewInstalled, 'O', gocui.ModNone, a.onPullOutdated, "pull outdated"},
+		{viewInstalled, 'a', gocui.ModNone, a.onToggleAge, "show age"},
+		{viewInstalled, 'b', gocui.ModNone, a.onBrowseLibrary, "browse library"},
+		{viewInstalled, 't', gocui.ModNone, a.onBrowseTags, "browse tags"},
+		{viewInstalled, '/', gocui.ModNone, a.onStartFilter, "filter"},
+		{viewInstalled, gocui.KeyEsc, gocui.ModNone, a.onClearFilter, "clear filter"},
+
+		{viewInstalled, 'n', gocui.ModNone, a.onStartCreate, "new model from this"},
+		{viewInstalled, gocui.KeySpace, gocui.ModNone, a.onToggleMark, "mark"},
+
+		{viewInstalled, gocui.MouseLeft, gocui.ModNone, a.onInstalledClick, "select"},
+		{viewInstalled, gocui.MouseWheelUp, gocui.ModNone, a.wheel(a.moveInstalled, -1), "scroll up"},
+		{viewInstalled, gocui.MouseWheelDown, gocui.ModNone, a.wheel(a.moveInstalled, 1), "scroll down"},
+
+		{viewRunning, gocui.KeyArrowUp, gocui.ModNone, a.onRunningUp, "move up"},
+		{viewRunning, gocui.KeyArrowDown, gocui.ModNone, a.onRunningDown, "move down"},
+		{viewRunning, 'k', gocui.ModNone, a.onRunningUp, "move up"},
+		{viewRunning, 'j', gocui.ModNone, a.onRunningDown, "move down"},
+		{viewRunning, gocui.KeyPgup, gocui.ModNone, pager(a.moveRunning, -1, false), "page up"},
+		{viewRunning, gocui.KeyPgdn, gocui.ModNone, pager(a.moveRunning, 1, false), "page down"},
+		{viewRunning, gocui.KeyCtrlU, gocui.ModNone, pager(a.moveRunning, -1, true), "half page up"},
+		{viewRunning, gocui.KeyCtrlD, gocui.ModNone, pager(a.moveRunning, 1, true), "half page down"},
+		{viewRunning, gocui.KeyEnter, gocui.ModNone, a.onShowRuntime, "runtime details"},
+		{viewRunning, 'u', gocui.ModNone, a.onUnload, "unload"},
+		{viewRunning, 'y', gocui.ModNone, a.onYankName, "yank name"},
+		{viewRunning, 'Y', gocui.ModNone, a.onYankDigest, "yank digest"},
+		{viewRunning, 'o', gocui.ModNone, a.onOpenModelPage, "open ollama.com page"},
+
+		{viewRunning, gocui.MouseLeft, gocui.ModNone, a.onRunningClick, "select"},
+		{viewRunning, gocui.MouseWheelUp, gocui.ModNone, a.wheel(a.moveRunning, -1), "scroll up"},
+		{viewRunning, gocui.MouseWheelDown, gocui.ModNone, a.wheel(a.moveRunning, 1), "scroll down"},
+
+		{viewStatus, gocui.MouseLeft, gocui.ModNone, a.onStatusClick, "focus"},
+		{viewStatus, gocui.KeyEnter, gocui.ModNone, a.onShowStatusLog, "expand log"},
+
+		{viewTasks, gocui.KeyArrowUp, gocui.ModNone, a.onTaskUp, "move up"},
+		{viewTasks, gocui.KeyArrowDown, gocui.ModNone, a.onTaskDown, "move down"},
+		{viewTasks, 'k', gocui.ModNone, a.onTaskUp, "move up"},
+		{viewTasks, 'j', gocui.ModNone, a.onTaskDown, "move down"},
+
+		{viewCreate, gocui.KeyEnter, gocui.ModNone, a.onCreateNext, "next"},
+		{viewCreate, gocui.KeyEsc, gocui.ModNone, a.onCreateBack, "back"},
+		{viewCreate, gocui.KeyArrowUp, gocui.ModNone, a.onCreateUp, "move up"},
+		{viewCreate, gocui.KeyArrowDown, gocui.ModNone, a.onCreateDown, "move down"},
+
+		{viewDetails, gocui.KeyEsc, gocui.ModNone, a.onCloseDetails, "close"},
+		{viewDetails, 'm', gocui.ModNone, a.onCopyModelfile, "copy Modelfile"},
+		{viewDetails, gocui.KeyArrowUp, gocui.ModNone, a.onScrollInfoUp, "scroll up"},
+		{viewDetails, gocui.KeyArrowDown, gocui.ModNone, a.onScrollInfoDown, "scroll down"},
+		{viewDetails, 'k', gocui.ModNone, a.onScrollInfoUp, "scroll up"},
+		{viewDetails, 'j', gocui.ModNone, a.onScrollInfoDown, "scroll down"},
+
+		{viewLibrary, gocui.KeyArrowUp, gocui.ModNone, a.onLibraryUp, "move up"},
+		{viewLibrary, gocui.KeyArrowDown, gocui.ModNone, a.onLibraryDown, "move down"},
+		{viewLibrary, gocui.KeyEnter, gocui.ModNone, a.onLibraryPull, "pull"},
+		{viewLibrary, '/', gocui.ModNone, a.onLibrarySearch, "search"},
+		{viewLibrary, 't', gocui.ModNone, a.onLibraryTags, "tags"},
+		{viewLibrary, 'o', gocui.ModNone, a.onLibraryOpenPage, "open ollama.com page"},
+		{viewLibrary, gocui.KeyEsc, gocui.ModNone, a.onCloseLibrary, "close"},
+
+		{viewChatInput, gocui.KeyEnter, gocui.ModNone, a.onChatSend, "send"},
+		{viewChatInput, gocui.KeyCtrlF, gocui.ModNone, a.onChatFormat, "format"},
+		{viewChatInput, gocui.KeyCtrlT, gocui.ModNone, a.onChatTools, "tools"},
+		{viewChatInput, gocui.KeyCtrlN, gocui.ModNone, a.onChatReset, "new chat"},
+		{viewChatInput, gocui.KeyEsc, gocui.ModNone, a.onChatEscape, "stop/close"},
+
+		{viewLogs, gocui.KeyEsc, gocui.ModNone, a.onCloseLogs, "close"},
+
+		{viewStatusLog, gocui.KeyArrowUp, gocui.ModNone, a.onStatusLogUp, "move up"},
+		{viewStatusLog, gocui.KeyArrowDown, gocui.ModNone, a.onStatusLogDown, "move down"},
+		{viewStatusLog, 'k', gocui.ModNone, a.onStatusLogUp, "move up"},
+		{viewStatusLog, 'j', gocui.ModNone, a.onStatusLogDown, "move down"},
+		{viewStatusLog, gocui.KeyPgup, gocui.ModNone, pager(a.moveStatusLog, -1, false), "page up"},
+		{viewStatusLog, gocui.KeyPgdn, gocui.ModNone, pager(a.moveStatusLog, 1, false), "page down"},
+		{viewStatusLog, 'g', gocui.ModNone, a.onStatusLogTop, "oldest"},
+		{viewStatusLog, 'G', gocui.ModNone, a.onStatusLogBottom, "newest"},
+		{viewStatusLog, '/', gocui.ModNone, a.onSearchStatusLog, "search"},
+		{viewStatusLog, 'n', gocui.ModNone, a.onStatusLogNext, "next match"},
+		{viewStatusLog, 'N', gocui.ModNone, a.onStatusLogPrev, "previous match"},
+		{viewStatusLog, 'c', gocui.ModNone, a.onCopyStatusLog, "copy"},
+		{viewStatusLog, gocui.KeyEsc, gocui.ModNone, a.onCloseStatusLog, "close"},
+
+		{viewInfo, gocui.KeyEsc, gocui.ModNone, a.onCloseInfo, "close"},
+		{viewInfo, gocui.KeyArrowUp, gocui.ModNone, a.onScrollInfoUp, "scroll up"},
+		{viewInfo, gocui.KeyArrowDown, gocui.ModNone, a.onScrollInfoDown, "scroll down"},
+		{viewInfo, 'c', gocui.ModNone, a.onCopyInfo, "copy"},
+
+		{viewFilter, gocui.KeyEnter, gocui.ModNone, a.filterInput.Submit, "keep filter"},
+		{viewFilter, gocui.KeyEsc, gocui.ModNone, a.onClearFilter, "clear filter"},
+		{viewFilter, gocui.KeyArrowUp, gocui.ModNone, a.onCursorUp, "move up"},
+		{viewFilter, gocui.KeyArrowDown, gocui.ModNone, a.onCursorDown, "move down"},
+
+		{viewPaletteInput, gocui.KeyEnter, gocui.ModNone, a.onPaletteRun, "run"},
+		{viewPaletteInput, gocui.KeyEsc, gocui.ModNone, a.onPaletteCancel, "cancel"},
+		{viewPaletteInput, gocui.KeyArrowUp, gocui.ModNone, a.onPaletteUp, "move up"},
+		{viewPaletteInput, gocui.KeyArrowDown, gocui.ModNone, a.onPaletteDown, "move down"},
+
+		{viewPrompt, gocui.KeyEnter, gocui.ModNone, a.prompt.Submit, "ok"},
+		{viewPrompt, gocui.KeyEsc, gocui.ModNone, a.prompt.Cancel, "cancel"},
+
+		{viewConfirm, 'y', gocui.ModNone, a.confirm.Yes, "yes"},
+		{viewConfirm, 'n', gocui.ModNone, a.confirm.No, "no"},
+		{viewConfirm, gocui.KeyEsc, gocui.ModNone, a.confirm.No, "no"},
+		{viewConfirm, gocui.KeyEnter, gocui.ModNone, a.confirm.No, "no"},
+	}
+	if a.config.Keymap == keymapVim {
+		table = a.vimBindings(table)
+	}
+	if a.debug {
+		table = append(table, a.debugBindings()...)
+	}
+	return table
+}
+
+// bindKeys validates the key binding table and registers it with the GUI.
+// Keys configured for actions in the config file replace the defaults; if
+// they are invalid or conflict, the error is reported in the status pane and
+// the defaults are used. Global bindings on keys that are needed for typing
+// are wrapped so that they pass the key through to the focused view while it
+// is editable.
+func (a *App) bindKeys() error {
+	table := a.bindings()
+	if err := validateBindings(table); err != nil {
+		return err
+	}
+	if custom, err := customBindings(table, a.config.Keybindings); err != nil {
+		a.logf("Keybindings: %v; using the default keys", err)
+	} else {
+		table = custom
+	}
+	return a.registerKeys(a.gui, table)
+}
+
+// rebindKeys replaces the registered key bindings with those of the current
+// configuration, as after a config reload. If the new bindings are invalid
+// or conflict, the previous ones stay in place and the error is returned.
+func (a *App) rebindKeys(g *gocui.Gui) error {
+	table := a.bindings()
+	if err := validateBindings(table); err != nil {
+		return err
+	}
+	table, err := customBindings(table, a.config.Keybindings)
+	if err != nil {
+		return err
+	}
+	views := make(map[string]bool)
+	for _, b := range a.keys {
+		if !views[b.view] {
+			views[b.view] = true
+			g.DeleteKeybindings(b.view)
+		}
+	}
+	return a.registerKeys(g, table)
+}
+
+// registerKeys registers table with g and makes it the table shown in the
+// legend and help. Global keys do nothing while a modal view has focus, and
+// input keys go to the editor of a focused editable view.
+func (a *App) registerKeys(g *gocui.Gui, table []binding) error {
+	a.keys = table
+	for _, b := range table {
+		handler := b.handler
+		if b.view == "" && isInputKey(b.key) {
+			handler = passThroughEditable(b.key, b.mod, handler)
+		}
+		if b.view == "" {
+			handler = suspendWhileModal(handler)
+		}
+		if err := g.SetKeybinding(b.view, b.key, b.mod, handler); err != nil {
+			return fmt.Errorf("binding %s for %s: %w", keyName(b.key), b.desc, err)
+		}
+	}
+	return nil
+}
+
+// validateBindings reports keys bound twice in the same scope and keys bound
+// both globally and in a view. gocui runs every matching handler, so either
+// would trigger two actions for a single key press.
+func validateBindings(table []binding) error {
+	type scopedKey struct {
+		view string
+		key  any
+		mod  gocui.Modifier
+	}
+	seen := make(map[scopedKey]binding, len(table))
+	var conflicts []string
+	for _, b := range table {
+		k := scopedKey{b.view, b.key, b.mod}
+		if prev, ok := seen[k]; ok && prev.desc != b.desc {
+			conflicts = append(conflicts, fmt.Sprintf("%s in %s: %q and %q", keyName(b.key), scopeName(b.view), prev.desc, b.desc))
+		}
+		seen[k] = b
+	}
+	for k, b := range seen {
+		if k.view == "" {
+			continue
+		}
+		if g, ok := seen[scopedKey{"", k.key, k.mod}]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s: global %q shadows %q in %s", keyName(k.key), g.desc, b.desc, k.view))
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("conflicting key bindings: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// isInputKey reports whether key is needed for typing into an editable view:
+// printable characters, space and the basic editing keys.
+func isInputKey(key any) bool {
+	switch k := key.(type) {
+	case rune:
+		return true
+	case gocui.Key:
+		switch k {
+		case gocui.KeySpace, gocui.KeyBackspace, gocui.KeyBackspace2, gocui.KeyDelete,
+			gocui.KeyArrowLeft, gocui.KeyArrowRight, gocui.KeyHome, gocui.KeyEnd:
+			return true
+		}
+	}
+	return false
+}
+
+// passThroughEditable wraps a global handler so that, while an editable view
+// has focus, the key is delivered to that view's editor instead.
+func passThroughEditable(key any, mod gocui.Modifier, handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if v == nil || !v.Editable {
+			return handler(g, v)
+		}
+		editor := v.Editor
+		if editor == nil {
+			editor = gocui.DefaultEditor
+		}
+		var k gocui.Key
+		var ch rune
+		switch kk := key.(type) {
+		case rune:
+			ch = kk
+		case gocui.Key:
+			k = kk
+		}
+		editor.Edit(v, k, ch, mod)
+		return nil
+	}
+}
+
+// modalViews are the views that take every key while they have focus, so
+// that a key meant for them never also triggers a global action.
+var modalViews = map[string]bool{viewConfirm: true}
+
+// suspendWhileModal wraps a global handler so that it does nothing while a
+// modal view has focus; the modal view's own bindings handle the key.
+func suspendWhileModal(handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if v != nil && modalViews[v.Name()] {
+			return nil
+		}
+		return handler(g, v)
+	}
+}
+
+// shadowedInputKeys returns the global bindings that are suspended while an
+// editable view has focus, for reporting when focus moves to such a view.
+func (a *App) shadowedInputKeys() []string {
+	var keys []string
+	for _, b := range a.keys {
+		if b.view == "" && isInputKey(b.key) {
+			keys = append(keys, keyName(b.key))
+		}
+	}
+	return keys
+}
+
+// focusEditable focuses an editable view and notes in the status pane which
+// global keys are suspended while typing in it.
+func (a *App) focusEditable(g *gocui.Gui, name string) error {
+	if _, err := g.SetCurrentView(name); err != nil {
+		return err
+	}
+	if keys := a.shadowedInputKeys(); len(keys) > 0 && !a.shadowReported {
+		a.shadowReported = true
+		a.logf("While typing, global keys %s are passed to the input", strings.Join(keys, " "))
+	}
+	return nil
+}
+
+// scopeName returns a readable name for a binding scope.
+func scopeName(view string) string {
+	if view == "" {
+		return "global scope"
+	}
+	return view
+}
+
+// keyName returns a readable name for a gocui key or rune.
+func keyName(key any) string {
+	switch k := key.(type) {
+	case rune:
+		return string(k)
+	case gocui.Key:
+		if name, ok := specialKeyNames[k]; ok {
+			return name
+		}
+		if k >= gocui.KeyCtrlA && k <= gocui.KeyCtrlZ {
+			return "Ctrl+" + string(rune('A'+k-gocui.KeyCtrlA))
+		}
+		return fmt.Sprintf("key(%d)", k)
+	default:
+		return fmt.Sprint(key)
+	}
+}
+
+// specialKeyNames names the non-character keys used in bindings.
+var specialKeyNames = map[gocui.Key]string{
+	gocui.KeyEnter:       "Enter",
+	gocui.KeyEsc:         "Esc",
+	gocui.KeyTab:         "Tab",
+	gocui.KeySpace:       "Space",
+	gocui.KeyBackspace:   "Backspace",
+	gocui.KeyBackspace2:  "Backspace",
+	gocui.KeyDelete:      "Delete",
+	gocui.KeyArrowUp:     "↑",
+	gocui.KeyArrowDown:   "↓",
+	gocui.KeyArrowLeft:   "←",
+	gocui.KeyArrowRight:  "→",
+	gocui.KeyHome:        "Home",
+	gocui.KeyEnd:         "End",
+	gocui.KeyPgup:        "PgUp",
+	gocui.KeyPgdn:        "PgDn",
+	gocui.MouseLeft:      "click",
+	gocui.MouseWheelUp:   "wheel↑",
+	gocui.MouseWheelDown: "wheel↓",
+	gocui.KeyF1:          "F1",
+	gocui.KeyF2:          "F2",
+	gocui.KeyF3:          "F3",
+	gocui.KeyF4:          "F4",
+	gocui.KeyF5:          "F5",
+	gocui.KeyF6:          "F6",
+	gocui.KeyF7:          "F7",
+	gocui.KeyF8:          "F8",
+	gocui.KeyF9:          "F9",
+	gocui.KeyF10:         "F10",
+	gocui.KeyF11:         "F11",
+	gocui.KeyF12:         "F12",
+	gocui.KeyInsert:      "Insert",
+}
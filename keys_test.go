@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jroimartin/gocui"
+)
+
+// TestSuspendWhileModal checks that global handlers do nothing while the
+// confirm dialog has focus, but run for other views and with no view.
+func TestSuspendWhileModal(t *testing.T) {
+	g := &gocui.Gui{}
+	var views []*gocui.View
+	for _, name := range []string{viewInstalled, viewConfirm} {
+		v, err := g.SetView(name, 0, 0, 40, 10)
+		if err != gocui.ErrUnknownView {
+			t.Fatalf("SetView %s: %v", name, err)
+		}
+		views = append(views, v)
+	}
+	calls := 0
+	handler := suspendWhileModal(func(*gocui.Gui, *gocui.View) error {
+		calls++
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		v    *gocui.View
+		want int
+	}{
+		{"installed", views[0], 1},
+		{"confirm", views[1], 0},
+		{"no view", nil, 1},
+	}
+	for _, tt := range tests {
+		calls = 0
+		if err := handler(g, tt.v); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if calls != tt.want {
+			t.Errorf("%s: handler ran %d times, want %d", tt.name, calls, tt.want)
+		}
+	}
+}
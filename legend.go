@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// legendFor returns a one-line summary of the keys active in the named view,
+// built from the key binding table. Keys sharing a description are listed
+// together. Global keys are included only for the main panes, since overlays
+// and forms are dismissed before most global actions make sense.
+func legendFor(table []binding, view string) string {
+	global := view == viewInstalled || view == viewRunning || view == viewStatus || view == viewTasks
+	var descs []string
+	keys := make(map[string][]string)
+	add := func(b binding) {
+		if _, ok := keys[b.desc]; !ok {
+			descs = append(descs, b.desc)
+		}
+		keys[b.desc] = append(keys[b.desc], keyName(b.key))
+	}
+	for _, b := range table {
+		if b.view == view {
+			add(b)
+		}
+	}
+	if global {
+		for _, b := range table {
+			if b.view == "" {
+				add(b)
+			}
+		}
+	}
+	parts := make([]string, 0, len(descs))
+	for _, d := range descs {
+		parts = append(parts, strings.Join(keys[d], "/")+" "+d)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// layoutLegend keeps the frameless footer below the status pane up to date
+// with the keys of the focused view. It only redraws when the text changes.
+func (a *App) layoutLegend(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	v, err := g.SetView(viewLegend, -1, maxY-2, maxX, maxY)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Frame = false
+		a.legend = ""
+	}
+	focus := viewInstalled
+	if cur := g.CurrentView(); cur != nil {
+		focus = cur.Name()
+	}
+	text := fitWidth(legendFor(a.keys, focus), maxX)
+	if text == a.legend {
+		return nil
+	}
+	a.legend = text
+	v.Clear()
+	fmt.Fprint(v, a.theme.paint(a.theme.accent, text))
+	return nil
+}
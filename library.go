@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// libraryBrowser holds the state of the library overlay, which shows either
+// search results or the tags of one model.
+type libraryBrowser struct {
+	query    string                // Current search query
+	results  []ollama.LibraryModel // Models matching the query
+	model    string                // Model whose tags are listed, empty while showing search results
+	tags     []ollama.LibraryTag   // Tags of model
+	selected int                   // Index of the selected row
+	loading  bool                  // Whether a request is in flight
+	err      error                 // Error of the last request, nil on success
+}
+
+// rows returns the number of selectable rows.
+func (b *libraryBrowser) rows() int {
+	if b.model != "" {
+		return len(b.tags)
+	}
+	return len(b.results)
+}
+
+// onBrowseLibrary asks for a search query and opens the library overlay
+// with the matching models. An empty query lists the most popular models.
+func (a *App) onBrowseLibrary(g *gocui.Gui, _ *gocui.View) error {
+	a.askInput(g, "Search ollama.com library", "", func(g *gocui.Gui, query string) error {
+		a.searchLibrary(query)
+		return nil
+	})
+	return nil
+}
+
+// searchLibrary opens the library overlay, if needed, and searches for
+// query in a background goroutine.
+func (a *App) searchLibrary(query string) {
+	a.library = &libraryBrowser{query: query, loading: true}
+	b := a.library
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		results, err := a.libraryClient.Search(ctx, query)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if a.library != b {
+				return nil
+			}
+			b.results, b.err, b.loading = results, err, false
+			a.drawLibrary()
+			return nil
+		})
+	}()
+}
+
+// browseTags opens the library overlay on the tags of the named model,
+// fetched in a background goroutine.
+func (a *App) browseTags(name string) {
+	ref := ollama.ParseModelRef(name)
+	if ref.Host != "" {
+		a.logf("Tags can only be listed for ollama.com models")
+		return
+	}
+	model := ref.Model
+	if ref.Namespace != "library" {
+		model = ref.Namespace + "/" + ref.Model
+	}
+	a.library = &libraryBrowser{model: model, loading: true}
+	b := a.library
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		tags, err := a.libraryClient.Tags(ctx, model)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if a.library != b {
+				return nil
+			}
+			b.tags, b.err, b.loading = tags, err, false
+			a.drawLibrary()
+			return nil
+		})
+	}()
+}
+
+// onBrowseTags opens the tag browser for the selected installed model.
+func (a *App) onBrowseTags(_ *gocui.Gui, _ *gocui.View) error {
+	if m := a.selectedModel(); m != nil {
+		a.browseTags(m.Name)
+	}
+	return nil
+}
+
+// onLibraryTags opens the tag browser for the selected search result.
+func (a *App) onLibraryTags(_ *gocui.Gui, _ *gocui.View) error {
+	b := a.library
+	if b == nil || b.model != "" || b.loading || b.selected >= len(b.results) {
+		return nil
+	}
+	a.browseTags(b.results[b.selected].Name)
+	return nil
+}
+
+// title returns the library overlay title, naming the query or model.
+func (b *libraryBrowser) title() string {
+	if b.model != "" {
+		return fmt.Sprintf("Tags of %s (Enter pull, o page, / search, Esc close)", b.model)
+	}
+	if b.query == "" {
+		return "Library: popular models (Enter pull, t tags, o page, / search, Esc close)"
+	}
+	return fmt.Sprintf("Library: %q (Enter pull, t tags, o page, / search, Esc close)", b.query)
+}
+
+// layoutLibrary draws the library overlay, if it is open, over the panes.
+func (a *App) layoutLibrary(g *gocui.Gui) error {
+	if a.library == nil {
+		if _, err := g.View(viewLibrary); err == nil {
+			return g.DeleteView(viewLibrary)
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	v, err := g.SetView(viewLibrary, 2, 1, maxX-3, maxY-4)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		if _, err := g.SetCurrentView(viewLibrary); err != nil {
+			return err
+		}
+		a.drawLibrary()
+	}
+	v.Title = a.library.title()
+	_, err = g.SetViewOnTop(viewLibrary)
+	return err
+}
+
+// libraryLine formats a search result: name, sizes, pull count, update
+// time and description.
+func libraryLine(m ollama.LibraryModel) string {
+	return fmt.Sprintf("%s  %s  %8s  %-14s  %s",
+		fitWidth(m.Name, 28), fitWidth(strings.Join(m.Sizes, ","), 18),
+		m.Pulls, fitWidth(m.Updated, 14), m.Description)
+}
+
+// tagLine formats a tag row, marking tags that are already installed.
+func (a *App) tagLine(t ollama.LibraryTag) string {
+	line := fmt.Sprintf("%s  %8s  %s", fitWidth(t.Name, 40), t.Size, t.Digest)
+	if a.isInstalled(t.Name) {
+		line += "  " + a.theme.paint(a.theme.accent, "(installed)")
+	}
+	return line
+}
+
+// drawLibrary renders the search results into the library overlay.
+func (a *App) drawLibrary() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewLibrary)
+		if err != nil || a.library == nil {
+			return nil
+		}
+		b := a.library
+		v.Clear()
+		v.Highlight = false
+		switch {
+		case b.loading:
+			fmt.Fprintln(v, "Loading...")
+			return nil
+		case b.err != nil:
+			fmt.Fprintln(v, a.theme.paint(a.theme.alert, "⚠ request failed — press / to search again"))
+			fmt.Fprintln(v)
+			fmt.Fprintln(v, b.err)
+			return nil
+		case b.rows() == 0 && b.model != "":
+			fmt.Fprintln(v, "(no tags found)")
+			return nil
+		case b.rows() == 0:
+			fmt.Fprintln(v, "(no models found)")
+			return nil
+		}
+		if b.model != "" {
+			fmt.Fprintf(v, "%s  %8s  %s\n", fitWidth("TAG", 40), "SIZE", "DIGEST")
+			for _, t := range b.tags {
+				fmt.Fprintln(v, a.tagLine(t))
+			}
+		} else {
+			fmt.Fprintf(v, "%s  %s  %8s  %-14s  %s\n",
+				fitWidth("NAME", 28), fitWidth("SIZES", 18), "PULLS", "UPDATED", "DESCRIPTION")
+			for _, m := range b.results {
+				fmt.Fprintln(v, libraryLine(m))
+			}
+		}
+		if b.selected >= b.rows() {
+			b.selected = b.rows() - 1
+		}
+		v.Highlight = true
+		return showRow(v, b.selected+1)
+	})
+}
+
+// onLibraryUp moves the library selection one row up.
+func (a *App) onLibraryUp(_ *gocui.Gui, _ *gocui.View) error {
+	if a.library != nil && a.library.selected > 0 {
+		a.library.selected--
+		a.drawLibrary()
+	}
+	return nil
+}
+
+// onLibraryDown moves the library selection one row down.
+func (a *App) onLibraryDown(_ *gocui.Gui, _ *gocui.View) error {
+	if a.library != nil && a.library.selected < a.library.rows()-1 {
+		a.library.selected++
+		a.drawLibrary()
+	}
+	return nil
+}
+
+// onLibrarySearch asks for a new query, starting from the current one.
+func (a *App) onLibrarySearch(g *gocui.Gui, _ *gocui.View) error {
+	if a.library == nil {
+		return nil
+	}
+	a.askInput(g, "Search ollama.com library", a.library.query, func(g *gocui.Gui, query string) error {
+		a.searchLibrary(query)
+		return nil
+	})
+	return nil
+}
+
+// onLibraryPull pulls the selected library model or tag, asking first if it
+// is already installed.
+func (a *App) onLibraryPull(g *gocui.Gui, _ *gocui.View) error {
+	b := a.library
+	if b == nil || b.loading || b.selected >= b.rows() {
+		return nil
+	}
+	var name string
+	if b.model != "" {
+		name = b.tags[b.selected].Name
+	} else {
+		name = b.results[b.selected].Name
+	}
+	if a.isInstalled(name) {
+		a.askConfirm(g, displayName(name)+" is already installed. Pull it again?", func(*gocui.Gui) error {
+			a.pull(name)
+			return nil
+		})
+		return nil
+	}
+	a.pull(name)
+	return nil
+}
+
+// onCloseLibrary closes the library overlay and returns focus to the
+// installed pane.
+func (a *App) onCloseLibrary(g *gocui.Gui, _ *gocui.View) error {
+	a.library = nil
+	if err := g.DeleteView(viewLibrary); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	_, err := g.SetCurrentView(viewInstalled)
+	return err
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// maxLogLines bounds the number of server log lines kept in the log pane.
+const maxLogLines = 1000
+
+// layoutLogs draws the server log overlay while log streaming is active.
+func (a *App) layoutLogs(g *gocui.Gui) error {
+	if a.logCancel == nil {
+		if _, err := g.View(viewLogs); err == nil {
+			return g.DeleteView(viewLogs)
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	v, err := a.setView(g, viewLogs, 1, maxY/3, maxX-2, maxY-3)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Server log (Esc close)"
+		v.Autoscroll = true
+		if _, err := g.SetCurrentView(viewLogs); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetViewOnTop(viewLogs)
+	return err
+}
+
+// drawLogs renders the retained server log lines.
+func (a *App) drawLogs() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewLogs)
+		if err != nil {
+			return nil
+		}
+		v.Clear()
+		for _, line := range a.logLines {
+			fmt.Fprintln(v, line)
+		}
+		return nil
+	})
+}
+
+// onShowLogs starts tailing the server log into the log pane. If the server
+// does not expose logs, the feature is disabled for the rest of the session.
+func (a *App) onShowLogs(_ *gocui.Gui, _ *gocui.View) error {
+	if a.logsUnsupported || a.logCancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.logCancel = cancel
+	a.logLines = nil
+	client := a.client
+	go func() {
+		err := client.StreamLogs(ctx, func(line string) {
+			a.safeUpdate(func(g *gocui.Gui) error {
+				a.logLines = append(a.logLines, line)
+				if len(a.logLines) > maxLogLines {
+					a.logLines = a.logLines[len(a.logLines)-maxLogLines:]
+				}
+				a.drawLogs()
+				return nil
+			})
+		})
+		a.safeUpdate(func(g *gocui.Gui) error {
+			switch {
+			case errors.Is(err, ollama.ErrNotSupported):
+				a.logsUnsupported = true
+				a.logf("Server logs are not available on this server")
+			case err != nil:
+				a.logErr("Logs", err)
+			}
+			if ctx.Err() == nil {
+				return a.onCloseLogs(g, nil)
+			}
+			return nil
+		})
+	}()
+	return nil
+}
+
+// onCloseLogs stops log streaming and closes the log pane.
+func (a *App) onCloseLogs(g *gocui.Gui, _ *gocui.View) error {
+	if a.logCancel != nil {
+		a.logCancel()
+		a.logCancel = nil
+	}
+	if err := g.DeleteView(viewLogs); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	_, err := g.SetCurrentView(viewInstalled)
+	return err
+}
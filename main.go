@@ -3,58 +3,215 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jroimartin/gocui"
 
 	"olazyllama/internal/ollama"
+	"olazyllama/internal/ui"
 )
 
 // View names for the GUI layout
 const (
-	viewInstalled = "installed" // Left pane showing installed models
-	viewRunning   = "running"   // Right pane showing running models
-	viewStatus    = "status"    // Bottom pane showing status messages
+	viewInstalled    = "installed"    // Left pane showing installed models
+	viewRunning      = "running"      // Right pane showing running models
+	viewStatus       = "status"       // Bottom pane showing status messages
+	viewDetails      = "details"      // Overlay showing details of the selected model
+	viewLogs         = "logs"         // Overlay tailing the server log
+	viewConfirm      = "confirm"      // Modal yes/no confirmation dialog
+	viewInfo         = "info"         // Read-only text overlay (e.g. statistics)
+	viewCreate       = "create"       // Guided create-model form
+	viewLegend       = "legend"       // Footer line listing the keys of the focused view
+	viewPrompt       = "prompt"       // Modal single-line text input
+	viewLibrary      = "library"      // Overlay listing models of the ollama.com library
+	viewChat         = "chat"         // Chat playground transcript
+	viewChatInput    = "chatinput"    // Chat playground message input
+	viewFilter       = "filter"       // Incremental filter input over the installed pane
+	viewPalette      = "palette"      // Command palette list of matching commands
+	viewPaletteInput = "paletteinput" // Command palette query input
+	viewProgress     = "progress"     // Overlay with progress bars of running transfers
+	viewTasks        = "tasks"        // Pane listing background tasks below the running pane
+	viewStatusLog    = "statuslog"    // Overlay with the full, searchable status history
 )
 
 // App represents the main application state and GUI components.
 // It manages the terminal interface, Ollama client connection, and model data.
 type App struct {
-	gui     *gocui.Gui     // Terminal GUI instance
-	client  *ollama.Client // Ollama API client
-	baseURL string         // Base URL for Ollama server
+	gui           *gocui.Gui      // Terminal GUI instance
+	client        ollama.API      // Ollama API client
+	libraryClient *ollama.Library // Client of the public model library
+	baseURL       string          // Base URL for Ollama server
+	backend       string          // Server API in use, BackendOllama or BackendOpenAI
+
+	connMu      sync.RWMutex // Guards client, baseURL and hostGen, which switchHost replaces on the GUI goroutine
+	hostGen     int          // Incremented on every host switch, so results from the previous server are dropped
+	trace       io.Writer    // Debug trace destination applied to every client, nil without --debug
+	traceBodies bool         // Whether the debug trace includes request and response bodies
+
+	modelsDir string // Models directory used by local-only features
+	debug     bool   // Whether debug-only key bindings are enabled
 
 	installed []ollama.Model // List of locally installed models
 	running   []ollama.Model // List of currently running models
+	order     []int          // Indices into installed in display order
+	selected  int            // Position of the selected model within order
+	loaded    bool           // Whether the first refresh has completed
+	offline   bool           // Whether the last refresh failed with a network error
+
+	installedNames map[string]bool // Normalized names of installed models
+	runningNames   map[string]bool // Normalized names of running models
+
+	installedErr error // Error from the last installed-models refresh, nil on success
+	runningErr   error // Error from the last running-models refresh, nil on success
+
+	loadingInstalled atomic.Int32 // Installed-model fetches in flight, shown as a spinner in the title
+	loadingRunning   atomic.Int32 // Running-model fetches in flight, shown as a spinner in the title
+
+	updates map[string]updateState // Registry update check results keyed by model name
+
+	runningSelected int      // Index of the selected row in the running list
+	runningDetailed bool     // Whether running models are shown with VRAM, processor and expiry columns
+	groupByFamily   bool     // Whether installed models are grouped under family headers
+	runningFirst    bool     // Whether running models are listed first in the installed pane
+	showAge         bool     // Whether installed models are labeled with their age bucket
+	sortMode        sortMode // Ordering of the installed list, cycled with 's'
+
+	filter      string    // Fuzzy filter narrowing the installed list, empty for all models
+	filterInput *ui.Input // Filter input over the installed pane
+
+	marked map[string]bool // Names of models marked for batch operations
+
+	drawBuf       bytes.Buffer // Scratch buffer reused when rendering the installed pane
+	installedRows []int        // Position in order of each installed pane row, -1 for headers
+	installedWin  listWindow   // Rows of the installed pane currently shown
+
+	statusMu      sync.Mutex    // Guards statusHistory, which any goroutine may append to
+	statusHistory []statusEntry // Status messages with timestamps, oldest first
+	statusLog     *statusLog    // Open status log overlay, nil when closed
+	lastErr       *errorRecord  // Most recent error, shown in the error overlay
+	keys          []binding     // Registered key bindings, also used for the legend
+	legend        string        // Text currently shown in the key legend footer
+
+	config     *Config // User configuration
+	configPath string  // Path the configuration was loaded from
+	configErr  error   // Last error loading the config file; it is not overwritten while set
+
+	details       *ollama.ModelInfo // Details shown in the overlay, nil when closed
+	detailsName   string            // Name of the model shown in the details overlay
+	detailsDigest string            // Manifest digest of the model shown in the details overlay
 
-	statusLines []string // Recent status messages for display
+	detailsBlobs    []string // Blob paths of the model shown in details (local servers only)
+	detailsBlobsErr error    // Why blob paths could not be determined, if they couldn't
+
+	logLines        []string           // Server log lines shown in the log pane
+	logCancel       context.CancelFunc // Stops log streaming, nil when the log pane is closed
+	logsUnsupported bool               // Whether the server was found not to expose logs
+
+	tasks         []*task // Active tasks and the most recent finished ones, oldest first
+	nextTaskID    int     // ID assigned to the most recently started task
+	tasksExpanded bool    // Whether the tasks pane lists every task rather than a summary
+	taskSelected  int     // Index of the selected row in the tasks pane
+
+	zoomed string // View expanded to fill the screen with 'z', empty when none
+
+	shadowReported bool         // Whether suspended global keys were reported for editable views
+	pending        pendingChord // First key of a two-key vim command, zero when none
+	auto           autoRefresh  // Schedule of the periodic background refresh
+
+	chordActions map[string]func(*gocui.Gui, *gocui.View) error // Actions of two-key vim commands by description
+
+	confirm *ui.Confirm     // Confirmation dialog shared by destructive actions
+	info    *infoOverlay    // Open text overlay, nil when none
+	create  *createForm     // Open create-model form, nil when none
+	prompt  *ui.Input       // Text prompt shared by actions that take a string
+	library *libraryBrowser // Open library browser, nil when none
+	chat    *chatSession    // Open chat playground, nil when none
+	palette *commandPalette // Open command palette, nil when none
+
+	serverVersion string                  // Server version, empty until known
+	versionErr    error                   // Error of the last version check, nil if the server answered
+	warned        map[ollama.Feature]bool // Features already warned about as unsupported
+
+	theme     theme // Active color theme
+	forceMono bool  // Whether the terminal lacks color support, overriding the configured theme
 }
 
 // newApp creates a new App instance with the specified Ollama server URL.
 // If baseURL is empty, it defaults to the standard Ollama localhost address.
 func newApp(baseURL string) *App {
-	return &App{
-		client:  ollama.NewClient(baseURL),
-		baseURL: baseURL,
+	a := &App{
+		client:        ollama.NewClient(baseURL),
+		libraryClient: ollama.NewLibrary(),
+		baseURL:       baseURL,
+		modelsDir:     ollama.DefaultModelsDir(),
+		config:        &Config{},
+		updates:       make(map[string]updateState),
+		marked:        make(map[string]bool),
+		confirm:       ui.NewConfirm(viewConfirm),
+		warned:        make(map[ollama.Feature]bool),
+		theme:         themeDefault,
+
+		runningDetailed: true,
 	}
+	a.prompt = ui.NewInput(viewPrompt, a.focusEditable)
+	a.filterInput = ui.NewInput(viewFilter, a.focusEditable)
+	return a
 }
 
 // logf logs a formatted message to the status view.
-// Messages are stored in a rolling buffer of the last 5 lines.
+// Messages are kept with their time in a history of the last
+// maxStatusHistory, which the status log overlay shows in full.
+// It is safe to call from any goroutine.
 func (a *App) logf(format string, args ...any) {
-	line := fmt.Sprintf(format, args...)
-	a.statusLines = append(a.statusLines, line)
-	if len(a.statusLines) > 5 {
-		a.statusLines = a.statusLines[len(a.statusLines)-5:]
+	e := statusEntry{at: time.Now(), text: fmt.Sprintf(format, args...)}
+	a.statusMu.Lock()
+	a.statusHistory = append(a.statusHistory, e)
+	if len(a.statusHistory) > maxStatusHistory {
+		a.statusHistory = a.statusHistory[len(a.statusHistory)-maxStatusHistory:]
+	}
+	a.statusMu.Unlock()
+	a.drawStatus()
+	a.drawStatusLog()
+}
+
+// status returns the most recent status messages, shown in the status pane.
+func (a *App) status() []string {
+	a.statusMu.Lock()
+	defer a.statusMu.Unlock()
+	recent := a.statusHistory
+	if len(recent) > statusPaneLines {
+		recent = recent[len(recent)-statusPaneLines:]
+	}
+	lines := make([]string, len(recent))
+	for i, e := range recent {
+		lines[i] = e.text
 	}
+	return lines
+}
+
+// drawStatus renders the retained status messages into the status view.
+func (a *App) drawStatus() {
 	a.safeUpdate(func(g *gocui.Gui) error {
 		if v, err := g.View(viewStatus); err == nil {
 			v.Clear()
-			fmt.Fprint(v, strings.Join(a.statusLines, " | "))
+			for i, l := range a.status() {
+				if i > 0 {
+					io.WriteString(v, " | ")
+				}
+				io.WriteString(v, l)
+			}
 		}
 		return nil
 	})
@@ -69,143 +226,271 @@ func (a *App) safeUpdate(fn func(*gocui.Gui) error) {
 	a.gui.Update(fn)
 }
 
-// layout sets up the GUI layout with three views: installed models (left),
-// running models (right), and status messages (bottom).
+// layout sets up the GUI layout with four views: installed models (left),
+// running models above the tasks (right), and status messages (bottom). The
+// divider position and which panes are hidden follow the configuration.
+// Layout runs on every redraw, so pane contents are only drawn here when the
+// views are created; afterwards they are redrawn explicitly when their data
+// changes.
 func (a *App) layout(g *gocui.Gui) error {
 	maxX, maxY := g.Size()
-	statusH := 2
-	bodyH := maxY - statusH
+	statusH := 3
+	if a.config.HideStatus {
+		statusH = 0
+	}
+	bodyH := maxY - statusH - 1
 	if bodyH < 3 {
 		bodyH = maxY
 	}
 
-	halfX := maxX / 2
+	splitX := maxX
+	right := a.rightVisible()
+	if right {
+		splitX = maxX * a.config.split() / 100
+	}
 
-	if v, err := g.SetView(viewInstalled, 0, 0, halfX-1, bodyH-1); err != nil {
+	created := false
+	if v, err := a.setView(g, viewInstalled, 0, 0, splitX-1, bodyH-1); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
-		v.Title = "Installed Models"
+		created = true
+		v.Title = a.installedTitle()
 		v.Wrap = false
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		if _, err := g.SetCurrentView(viewInstalled); err != nil {
+			return err
+		}
 	}
 
-	if v, err := g.SetView(viewRunning, halfX, 0, maxX-1, bodyH-1); err != nil {
-		if err != gocui.ErrUnknownView {
+	if right {
+		if err := a.layoutRight(g, splitX, maxX, bodyH); err != nil {
 			return err
 		}
-		v.Title = "Running (ollama ps)"
-		v.Wrap = false
+	} else if err := removeViews(g, viewRunning, viewTasks); err != nil {
+		return err
 	}
 
-	if v, err := g.SetView(viewStatus, 0, bodyH, maxX-1, maxY-1); err != nil {
+	if a.config.HideStatus {
+		if err := removeViews(g, viewStatus); err != nil {
+			return err
+		}
+	} else if v, err := a.setView(g, viewStatus, 0, bodyH, maxX-1, maxY-2); err != nil {
 		if err != gocui.ErrUnknownView {
 			return err
 		}
-		v.Title = "Status"
+		v.Title = a.statusTitle()
 		fmt.Fprint(v, "Ready")
+		if len(a.status()) > 0 {
+			a.drawStatus()
+		}
 	}
 
-	a.drawInstalled()
-	a.drawRunning()
+	if err := a.layoutZoom(g); err != nil {
+		return err
+	}
+
+	if err := a.layoutLegend(g); err != nil {
+		return err
+	}
+	if err := a.layoutFilter(g); err != nil {
+		return err
+	}
+	if err := a.layoutProgress(g); err != nil {
+		return err
+	}
+
+	if err := a.layoutDetails(g); err != nil {
+		return err
+	}
+	if err := a.layoutLogs(g); err != nil {
+		return err
+	}
+	if err := a.layoutStatusLog(g); err != nil {
+		return err
+	}
+	if err := a.layoutLibrary(g); err != nil {
+		return err
+	}
+	if err := a.layoutChat(g); err != nil {
+		return err
+	}
+	if err := a.layoutCreate(g); err != nil {
+		return err
+	}
+	if err := a.layoutInfo(g); err != nil {
+		return err
+	}
+	if err := a.layoutPalette(g); err != nil {
+		return err
+	}
+	if err := a.layoutPrompt(g); err != nil {
+		return err
+	}
+	if err := a.confirm.Layout(g); err != nil {
+		return err
+	}
+
+	// The installed pane renders only the rows that fit, so it is redrawn
+	// when resizing changes how many do.
+	if v, err := g.View(viewInstalled); created || (err == nil && a.installedWin.resized(v)) {
+		a.drawInstalled()
+	}
 	return nil
 }
 
-// drawInstalled updates the installed models view with the current list.
-// Shows model names and sizes in a formatted display.
-func (a *App) drawInstalled() {
-	a.safeUpdate(func(g *gocui.Gui) error {
-		v, err := g.View(viewInstalled)
-		if err != nil {
-			return nil
-		}
-		v.Clear()
-		if len(a.installed) == 0 {
-			fmt.Fprintln(v, "(no models installed)")
-			return nil
-		}
-		for _, m := range a.installed {
-			line := m.Name
-			if m.Size > 0 {
-				line = fmt.Sprintf("%-40s  %s", m.Name, ollama.HumanSize(m.Size))
-			}
-			fmt.Fprintln(v, line)
-		}
-		return nil
-	})
+// drawPaneError renders an inline error state into a pane whose last refresh
+// failed, so stale content is not mistaken for current data.
+func (a *App) drawPaneError(v *gocui.View, what string, err error) {
+	v.Highlight = false
+	fmt.Fprintln(v, a.theme.paint(a.theme.alert, "⚠ failed to load "+what+" — press r"))
+	fmt.Fprintln(v)
+	if hint := a.errorHint(err); hint != "" {
+		fmt.Fprintln(v, hint)
+	}
+	fmt.Fprintln(v, err)
 }
 
-// drawRunning updates the running models view with currently active models.
-func (a *App) drawRunning() {
-	a.safeUpdate(func(g *gocui.Gui) error {
-		v, err := g.View(viewRunning)
-		if err != nil {
-			return nil
-		}
-		v.Clear()
-		if len(a.running) == 0 {
-			fmt.Fprintln(v, "(nothing running)")
-			return nil
-		}
-		for _, m := range a.running {
-			fmt.Fprintln(v, m.Name)
-		}
-		return nil
-	})
+// fetch retrieves the installed and running model lists from client.
+// It is shared by the TUI refresh and the plain-text watch mode.
+func fetch(ctx context.Context, client ollama.API) (installed, running []ollama.Model, installedErr, runningErr error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	installed, installedErr = client.ListLocalModels(ctx)
+	running, runningErr = client.ListRunning(ctx)
+	return installed, running, installedErr, runningErr
 }
 
 // refreshAll fetches the latest model data from Ollama in a background goroutine.
 // Updates both installed and running model lists with error handling.
 func (a *App) refreshAll() {
+	a.refreshAllThen(nil)
+}
+
+// refreshAllThen is refreshAll, calling done, if not nil, on the GUI
+// goroutine once the result has been applied.
+func (a *App) refreshAllThen(done func()) {
 	a.logf("Refreshing...")
+	ctx, finish := a.startQuietTask("refresh")
+	a.loadingInstalled.Add(1)
+	a.loadingRunning.Add(1)
+	client, gen := a.client, a.hostGen
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		installed, err1 := a.client.ListLocalModels(ctx)
-		running, err2 := a.client.ListRunning(ctx)
-
-		a.safeUpdate(func(g *gocui.Gui) error {
-			if err1 != nil {
-				a.logf("Installed: %v", err1)
-			} else {
-				a.installed = installed
-			}
-			if err2 != nil {
-				a.logf("Running: %v", err2)
-			} else {
-				a.running = running
-			}
-			a.drawInstalled()
-			a.drawRunning()
-			if err1 == nil && err2 == nil {
-				a.logf("Refreshed")
+		installed, running, err1, err2 := fetchWithRetry(ctx, client)
+		a.loadingInstalled.Add(-1)
+		a.loadingRunning.Add(-1)
+		finish(errors.Join(err1, err2))
+		a.safeUpdate(func(*gocui.Gui) error {
+			a.applyRefresh(refreshResult{
+				gen:          gen,
+				installed:    installed,
+				running:      running,
+				installedErr: err1,
+				runningErr:   err2,
+			})
+			if done != nil {
+				done()
 			}
 			return nil
 		})
 	}()
 }
 
-// bindKeys sets up keyboard shortcuts for the application.
-// Supports Ctrl+C, q (quit), r, and Ctrl+R (refresh).
-func (a *App) bindKeys() error {
-	if err := a.gui.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, a.onQuit); err != nil {
-		return err
+// refreshResult is the outcome of fetching the model lists for a refresh.
+type refreshResult struct {
+	gen           int            // Host generation the lists were fetched from
+	installed     []ollama.Model // Installed models, nil on error or when not fetched
+	running       []ollama.Model // Running models, nil on error or when not fetched
+	installedErr  error          // Error fetching the installed models
+	runningErr    error          // Error fetching the running models
+	skipInstalled bool           // Whether the installed models were not fetched
+	skipRunning   bool           // Whether the running models were not fetched
+	quiet         bool           // Whether this is an automatic refresh, which only reports new errors
+}
+
+// applyRefresh updates the panes with the result of a refresh and records
+// it in the metrics. Errors are logged and run the error hook; a refresh
+// that succeeds after the server was unreachable logs the reconnect and
+// checks the server version again. A result fetched before the host was
+// switched is dropped.
+func (a *App) applyRefresh(r refreshResult) {
+	if r.gen != a.hostGen {
+		return
 	}
-	if err := a.gui.SetKeybinding("", 'q', gocui.ModNone, a.onQuit); err != nil {
-		return err
+	first := !a.loaded
+	a.loaded = true
+	wasOffline := a.offline
+	a.offline = isTransient(r.installedErr) || isTransient(r.runningErr)
+	a.safeUpdate(func(g *gocui.Gui) error {
+		a.updateStatusTitle(g)
+		return nil
+	})
+	recordRefresh(r)
+	if !r.skipInstalled {
+		a.reportRefreshErr("Installed", r.installedErr, a.installedErr, r.quiet)
+		a.installedErr = r.installedErr
+		if r.installedErr == nil && !sameModels(a.installed, r.installed) {
+			a.setInstalled(r.installed)
+		}
+		if first && r.installedErr == nil {
+			a.checkDefaultModel()
+		}
 	}
-	if err := a.gui.SetKeybinding("", 'r', gocui.ModNone, a.onRefresh); err != nil {
-		return err
+	if !r.skipRunning {
+		a.reportRefreshErr("Running", r.runningErr, a.runningErr, r.quiet)
+		a.runningErr = r.runningErr
+		if r.runningErr == nil {
+			a.setRunning(r.running)
+		}
 	}
-	if err := a.gui.SetKeybinding("", gocui.KeyCtrlR, gocui.ModNone, a.onRefresh); err != nil {
-		return err
+	a.drawInstalled()
+	a.drawRunning()
+	if r.installedErr != nil || r.runningErr != nil {
+		return
+	}
+	switch {
+	case wasOffline:
+		a.logf("Reconnected to %s", a.baseURL)
+		a.checkVersion()
+	case !r.quiet:
+		a.logf("Refreshed")
 	}
-	return nil
+}
+
+// reportRefreshErr logs a failed list refresh and runs the error hook. An
+// automatic refresh only reports an error the pane was not already showing.
+func (a *App) reportRefreshErr(pane string, err, shown error, quiet bool) {
+	if err == nil || (quiet && shown != nil) {
+		return
+	}
+	a.logErr(pane, err)
+	a.runHook(eventError, hookEnv{Error: err.Error()})
 }
 
 // onQuit handles the quit key binding and terminates the application.
-func (a *App) onQuit(_ *gocui.Gui, _ *gocui.View) error {
-	return gocui.ErrQuit
+// If operations are still running, the user is asked to confirm first; on
+// confirmation they are canceled before quitting.
+func (a *App) onQuit(g *gocui.Gui, _ *gocui.View) error {
+	active := a.activeTasks(false)
+	if len(active) == 0 {
+		return gocui.ErrQuit
+	}
+	if a.confirm.Open() {
+		return nil
+	}
+	var msg string
+	if len(active) == 1 {
+		msg = fmt.Sprintf("An operation is running (%s). Quit anyway?", active[0].desc)
+	} else {
+		msg = fmt.Sprintf("%d operations are running. Quit anyway?", len(active))
+	}
+	a.askConfirm(g, msg, func(*gocui.Gui) error {
+		a.cancelTasks()
+		return gocui.ErrQuit
+	})
+	return nil
 }
 
 // onRefresh handles the refresh key binding and triggers a data refresh.
@@ -214,10 +499,201 @@ func (a *App) onRefresh(_ *gocui.Gui, _ *gocui.View) error {
 	return nil
 }
 
+// onCopyStatus copies all retained status messages to the clipboard. The
+// confirmation is shown transiently in the status view rather than logged, so
+// it does not displace the messages that were just copied.
+func (a *App) onCopyStatus(g *gocui.Gui, _ *gocui.View) error {
+	lines := a.status()
+	if len(lines) == 0 {
+		return nil
+	}
+	msg := "Copied status to clipboard"
+	if err := copyToClipboard(strings.Join(lines, "\n")); err != nil {
+		msg = fmt.Sprintf("Copy status: %v", err)
+	}
+	if v, err := g.View(viewStatus); err == nil {
+		v.Clear()
+		fmt.Fprint(v, msg)
+	}
+	return nil
+}
+
+// onPreload loads the selected installed model into memory.
+func (a *App) onPreload(g *gocui.Gui, _ *gocui.View) error {
+	if a.confirmBatch(g, "Load", func(name string) { a.preload(name, a.config.keepAliveFor(name)) }) {
+		return nil
+	}
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	a.preload(m.Name, a.config.keepAliveFor(m.Name))
+	return nil
+}
+
+// onPreloadWith prompts for a keep-alive, prefilled with the configured one,
+// and loads the selected installed model with it. This keeps a model warm for
+// longer than usual, e.g. ahead of a demo.
+func (a *App) onPreloadWith(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	name := m.Name
+	a.askInput(g, "Load "+displayName(name)+" with keep alive (e.g. 1h, -1 forever)", a.config.keepAliveFor(name), func(_ *gocui.Gui, keepAlive string) error {
+		if keepAlive != "" {
+			if err := validateKeepAlive(keepAlive); err != nil {
+				a.logf("Invalid keep alive %q: %v", keepAlive, err)
+				return nil
+			}
+		}
+		a.preload(name, keepAlive)
+		return nil
+	})
+	return nil
+}
+
+// onPreloadDefault loads the configured default model into memory, regardless
+// of the current selection.
+func (a *App) onPreloadDefault(_ *gocui.Gui, _ *gocui.View) error {
+	if a.config.DefaultModel == "" {
+		a.logf("No default_model set in config")
+		return nil
+	}
+	a.preload(a.config.DefaultModel, a.config.keepAliveFor(a.config.DefaultModel))
+	return nil
+}
+
+// preload loads the named model into memory in a background goroutine.
+// An empty keepAlive uses the server default.
+func (a *App) preload(name, keepAlive string) {
+	if keepAlive != "" {
+		a.logf("Loading %s (keep alive %s)...", name, keepAlive)
+	} else {
+		a.logf("Loading %s...", name)
+	}
+	ctx, finish := a.startTask("load " + name)
+	client := a.client
+	go func() {
+		err := client.Preload(ctx, name, keepAlive)
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if isCanceled(err) {
+				a.logf("Canceled load %s", name)
+				return nil
+			}
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Load "+name, err)
+				a.runHook(eventError, hookEnv{Model: name, Error: err.Error()})
+				return nil
+			}
+			a.logf("Loaded %s", name)
+			a.refreshAll()
+			return nil
+		})
+	}()
+}
+
+// checkDefaultModel warns when the configured default model is not installed.
+func (a *App) checkDefaultModel() {
+	name := a.config.DefaultModel
+	if name == "" {
+		return
+	}
+	for _, m := range a.installed {
+		if ollama.SameModel(m.Name, name) {
+			return
+		}
+	}
+	a.logf("Warning: default model %s is not installed", name)
+}
+
 // main initializes and runs the Ollama model manager GUI application.
 // Sets up the terminal interface, binds keyboard shortcuts, and starts the main loop.
 func main() {
-	app := newApp("http://localhost:11434")
+	watch := flag.Bool("watch", false, "print a plain-text dashboard instead of the TUI")
+	interval := flag.Duration("refresh-interval", 5*time.Second, "redraw interval for --watch")
+	host := flag.String("host", "", "Ollama server URL, or unix:///path/to/socket (default from config, $OLLAMA_HOST, or "+defaultBaseURL+")")
+	backendName := flag.String("backend", "", "server API: ollama or openai for OpenAI-compatible servers (default from config, or ollama)")
+	modelsDir := flag.String("models-dir", "", "Ollama models directory for local features (default $OLLAMA_MODELS or ~/.ollama/models)")
+	debug := flag.Bool("debug", false, "enable debug keys (F5 fake error, F6 empty lists, F7 large list) and trace requests to --debug-log")
+	debugLog := flag.String("debug-log", filepath.Join(os.TempDir(), "olazyllama-debug.log"), "file request traces are written to with --debug")
+	debugBodies := flag.Bool("debug-bodies", false, "include request and response bodies in the --debug trace")
+	metricsAddr := flag.String("metrics-addr", "", "serve Prometheus metrics on this address (e.g. :9090)")
+	flag.Parse()
+
+	if *metricsAddr != "" {
+		go func() {
+			if err := serveMetrics(*metricsAddr); err != nil {
+				log.Printf("metrics: %v", err)
+			}
+		}()
+	}
+
+	configPath := defaultConfigPath()
+	cfg, cfgErr := loadConfig(configPath)
+	app := newApp(ResolveBaseURL(*host, cfg))
+	app.configPath = configPath
+	app.modelsDir = ResolveModelsDir(*modelsDir)
+	app.debug = *debug
+	if *debug {
+		f, err := os.OpenFile(*debugLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			log.Fatalf("debug log: %v", err)
+		}
+		defer f.Close()
+		app.trace, app.traceBodies = f, *debugBodies
+		if c, ok := app.client.(*ollama.Client); ok {
+			c.EnableTrace(f, *debugBodies)
+		}
+	}
+	var tlsErr error
+	app.configErr = cfgErr
+	app.forceMono = !colorSupported()
+	if cfgErr == nil {
+		app.config = cfg
+		app.runningDetailed = !cfg.CompactRunning
+		if c, ok := app.client.(*ollama.Client); ok {
+			tlsErr = cfg.configureClient(c)
+		}
+		if err := cfg.configureLibrary(app.libraryClient); err != nil && tlsErr == nil {
+			tlsErr = err
+		}
+		app.theme = themeByName(cfg.Theme)
+	}
+	if app.forceMono {
+		app.theme = themeMono
+	}
+	backend := ResolveBackend(*backendName, app.config)
+	if err := validateBackend(backend); err != nil {
+		log.Fatal(err)
+	}
+	app.backend = backend
+	if c, ok := app.client.(*ollama.Client); ok && backend == BackendOpenAI {
+		app.client = ollama.NewOpenAIClient(c)
+	}
+
+	var modelsDirErr error
+	if app.client.IsLocal() {
+		modelsDirErr = checkModelsDir(app.modelsDir)
+	}
+
+	if *watch {
+		if cfgErr != nil {
+			log.Printf("config: %v", cfgErr)
+		}
+		if modelsDirErr != nil {
+			log.Printf("models dir: %v", modelsDirErr)
+		}
+		if tlsErr != nil {
+			log.Printf("%v", tlsErr)
+		}
+		if err := app.runWatch(*interval); err != nil {
+			log.Fatalf("watch: %v", err)
+		}
+		return
+	}
 
 	g, err := gocui.NewGui(gocui.OutputNormal)
 	if err != nil {
@@ -225,12 +701,34 @@ func main() {
 	}
 	defer g.Close()
 	app.gui = g
+	g.InputEsc = true
+	g.Mouse = !app.config.DisableMouse
+	app.applyTheme()
 
 	g.SetManagerFunc(app.layout)
 	if err := app.bindKeys(); err != nil {
 		log.Fatalf("keybindings: %v", err)
 	}
 
+	if cfgErr != nil {
+		app.logf("Config: %v", cfgErr)
+	}
+	if modelsDirErr != nil {
+		app.logf("Warning: models dir: %v", modelsDirErr)
+	}
+	if tlsErr != nil {
+		app.logf("Warning: %v", tlsErr)
+	}
+	if app.config.TLS != nil && app.config.TLS.InsecureSkipVerify {
+		app.logf("Warning: TLS certificate verification is disabled")
+	}
+	if *debug {
+		app.logf("Debug: tracing requests to %s", *debugLog)
+	}
+	app.checkVersion()
+	app.monitorHealth()
+	app.monitorRefresh()
+	app.monitorSpinner()
 	app.refreshAll()
 
 	if err := g.MainLoop(); err != nil && err != gocui.ErrQuit {
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// onToggleMark marks or unmarks the selected model for a batch operation and
+// moves the selection down, so consecutive models can be marked quickly.
+func (a *App) onToggleMark(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	if a.marked[m.Name] {
+		delete(a.marked, m.Name)
+	} else {
+		a.marked[m.Name] = true
+	}
+	if v, err := g.View(viewInstalled); err == nil {
+		v.Title = a.installedTitle()
+	}
+	if a.selected < len(a.order)-1 {
+		a.selected++
+	}
+	a.drawInstalled()
+	return nil
+}
+
+// markedNames returns the marked models that are still installed, in the
+// server's order, dropping marks of models that have gone.
+func (a *App) markedNames() []string {
+	if len(a.marked) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(a.marked))
+	present := make(map[string]bool, len(a.marked))
+	for _, m := range a.installed {
+		if a.marked[m.Name] {
+			names = append(names, m.Name)
+			present[m.Name] = true
+		}
+	}
+	for name := range a.marked {
+		if !present[name] {
+			delete(a.marked, name)
+		}
+	}
+	return names
+}
+
+// clearMarks unmarks all models and redraws the installed pane.
+func (a *App) clearMarks(g *gocui.Gui) {
+	a.marked = make(map[string]bool)
+	if v, err := g.View(viewInstalled); err == nil {
+		v.Title = a.installedTitle()
+	}
+	a.drawInstalled()
+}
+
+// batchMessage builds a confirmation question for applying verb to names,
+// listing every affected model on its own line.
+func batchMessage(verb string, names []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d models?", verb, len(names))
+	for _, name := range names {
+		b.WriteString("\n  " + displayName(name))
+	}
+	return b.String()
+}
+
+// confirmBatch asks once for confirmation and then runs action on every
+// marked model, clearing the marks. It reports false when no model is
+// marked, leaving the caller to act on the selection instead.
+func (a *App) confirmBatch(g *gocui.Gui, verb string, action func(name string)) bool {
+	names := a.markedNames()
+	if len(names) == 0 {
+		return false
+	}
+	a.askConfirm(g, batchMessage(verb, names), func(g *gocui.Gui) error {
+		for _, name := range names {
+			action(name)
+		}
+		a.clearMarks(g)
+		return nil
+	})
+	return true
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+
+	"olazyllama/internal/ollama"
+)
+
+// Instrumented counters and gauges, published via expvar and, with
+// --metrics-addr, in Prometheus text format.
+var (
+	metricRefreshes      = expvar.NewInt("refreshes")        // Completed refreshes
+	metricRefreshErrors  = expvar.NewInt("refresh_errors")   // Refreshes where a list call failed
+	metricErrors         = expvar.NewInt("errors")           // Failed operations of any kind
+	metricPulls          = expvar.NewInt("pulls")            // Completed model pulls
+	metricDeletes        = expvar.NewInt("deletes")          // Deleted models
+	metricInstalled      = expvar.NewInt("installed_models") // Installed model count at last refresh
+	metricInstalledBytes = expvar.NewInt("installed_bytes")  // Total size of installed models at last refresh
+)
+
+// promMetric describes how an expvar value is exposed to Prometheus.
+type promMetric struct {
+	name  string      // Prometheus metric name
+	kind  string      // "counter" or "gauge"
+	help  string      // HELP text
+	value *expvar.Int // Source value
+}
+
+// promMetrics lists the metrics served on /metrics.
+var promMetrics = []promMetric{
+	{"olazyllama_refreshes_total", "counter", "Completed model list refreshes.", metricRefreshes},
+	{"olazyllama_refresh_errors_total", "counter", "Refreshes in which a list request failed.", metricRefreshErrors},
+	{"olazyllama_errors_total", "counter", "Failed operations of any kind.", metricErrors},
+	{"olazyllama_pulls_total", "counter", "Completed model pulls.", metricPulls},
+	{"olazyllama_deletes_total", "counter", "Deleted models.", metricDeletes},
+	{"olazyllama_installed_models", "gauge", "Number of installed models.", metricInstalled},
+	{"olazyllama_installed_bytes", "gauge", "Total size of installed models in bytes.", metricInstalledBytes},
+}
+
+// recordRefresh updates the refresh metrics from the result of a refresh.
+// The installed gauges are left alone if the installed list was not fetched.
+func recordRefresh(r refreshResult) {
+	metricRefreshes.Add(1)
+	if r.installedErr != nil || r.runningErr != nil {
+		metricRefreshErrors.Add(1)
+		metricErrors.Add(1)
+	}
+	if !r.skipInstalled && r.installedErr == nil {
+		st := ollama.Stats(r.installed)
+		metricInstalled.Set(int64(st.Count))
+		metricInstalledBytes.Set(st.TotalSize)
+	}
+}
+
+// writePrometheus writes all metrics in the Prometheus text exposition format.
+func writePrometheus(w io.Writer) error {
+	for _, m := range promMetrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n",
+			m.name, m.help, m.name, m.kind, m.name, m.value.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metricsHandler serves the metrics in Prometheus text format.
+func metricsHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writePrometheus(w)
+}
+
+// serveMetrics serves /metrics (Prometheus) and /debug/vars (expvar) on addr.
+// It blocks until the server fails.
+func serveMetrics(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", metricsHandler)
+	mux.Handle("/debug/vars", expvar.Handler())
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,79 @@
+package main
+
+import "github.com/jroimartin/gocui"
+
+// overlayOpen reports whether an overlay, dialog or form is open. Mouse
+// actions on the panes beneath are ignored meanwhile, so that a click cannot
+// move focus away from a dialog waiting for an answer.
+func (a *App) overlayOpen() bool {
+	return a.confirm.Open() || a.info != nil || a.statusLog != nil || a.create != nil || a.prompt.Open() ||
+		a.details != nil || a.library != nil || a.chat != nil || a.logCancel != nil ||
+		a.palette != nil || a.filterInput.Open()
+}
+
+// clickedRow focuses the clicked pane and returns the buffer row that was
+// clicked, or -1 if the click should be ignored.
+func (a *App) clickedRow(g *gocui.Gui, v *gocui.View) (int, error) {
+	if v == nil || a.overlayOpen() {
+		return -1, nil
+	}
+	if _, err := g.SetCurrentView(v.Name()); err != nil {
+		return -1, err
+	}
+	_, oy := v.Origin()
+	_, cy := v.Cursor()
+	return oy + cy, nil
+}
+
+// onInstalledClick focuses the installed pane and selects the clicked model.
+// Clicks on family headers only focus the pane.
+func (a *App) onInstalledClick(g *gocui.Gui, v *gocui.View) error {
+	row, err := a.clickedRow(g, v)
+	if err != nil || row < 0 {
+		return err
+	}
+	row += a.installedWin.top
+	if row < len(a.installedRows) && a.installedRows[row] >= 0 {
+		a.selected = a.installedRows[row]
+	}
+	a.drawInstalled()
+	a.drawRunning()
+	return nil
+}
+
+// onRunningClick focuses the running pane and selects the clicked model.
+func (a *App) onRunningClick(g *gocui.Gui, v *gocui.View) error {
+	row, err := a.clickedRow(g, v)
+	if err != nil || row < 0 {
+		return err
+	}
+	if a.runningDetailed {
+		row-- // column header
+	}
+	if row >= 0 && row < len(a.running) {
+		a.runningSelected = row
+	}
+	a.drawInstalled()
+	a.drawRunning()
+	return nil
+}
+
+// onStatusClick focuses the status pane.
+func (a *App) onStatusClick(g *gocui.Gui, v *gocui.View) error {
+	if _, err := a.clickedRow(g, v); err != nil {
+		return err
+	}
+	a.drawRunning()
+	return nil
+}
+
+// wheel returns a handler moving a selection by n rows with move when the
+// wheel turns over its pane.
+func (a *App) wheel(move func(n int), n int) func(*gocui.Gui, *gocui.View) error {
+	return func(_ *gocui.Gui, _ *gocui.View) error {
+		if !a.overlayOpen() {
+			move(n)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// infoOverlay is a read-only, dismissible text overlay such as the stats view.
+type infoOverlay struct {
+	title string // Overlay title
+	body  string // Text shown in the overlay
+}
+
+// showInfo opens a read-only text overlay, replacing any one already open.
+func (a *App) showInfo(g *gocui.Gui, title, body string) error {
+	if err := g.DeleteView(viewInfo); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	a.info = &infoOverlay{title: title, body: body}
+	return nil
+}
+
+// layoutInfo draws the text overlay, if one is open, centered on screen.
+func (a *App) layoutInfo(g *gocui.Gui) error {
+	if a.info == nil {
+		if _, err := g.View(viewInfo); err == nil {
+			return g.DeleteView(viewInfo)
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	x0, y0 := maxX/6, maxY/6
+	v, err := a.setView(g, viewInfo, x0, y0, maxX-x0-1, maxY-y0-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = a.info.title + " (↑↓ scroll, c copy, Esc close)"
+		v.Wrap = true
+		fmt.Fprint(v, a.info.body)
+		if _, err := g.SetCurrentView(viewInfo); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetViewOnTop(viewInfo)
+	return err
+}
+
+// onCloseInfo closes the text overlay and returns focus to the installed pane.
+func (a *App) onCloseInfo(g *gocui.Gui, _ *gocui.View) error {
+	a.info = nil
+	if err := g.DeleteView(viewInfo); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	_, err := g.SetCurrentView(viewInstalled)
+	return err
+}
+
+// onScrollInfoUp scrolls a text overlay, such as the info or details
+// overlay, up one line.
+func (a *App) onScrollInfoUp(_ *gocui.Gui, v *gocui.View) error {
+	ox, oy := v.Origin()
+	if oy > 0 {
+		return v.SetOrigin(ox, oy-1)
+	}
+	return nil
+}
+
+// onScrollInfoDown scrolls a text overlay down one line.
+func (a *App) onScrollInfoDown(_ *gocui.Gui, v *gocui.View) error {
+	ox, oy := v.Origin()
+	_, h := v.Size()
+	if oy+h < len(v.ViewBufferLines()) {
+		return v.SetOrigin(ox, oy+1)
+	}
+	return nil
+}
+
+// onCopyInfo copies the text of the overlay to the clipboard.
+func (a *App) onCopyInfo(_ *gocui.Gui, _ *gocui.View) error {
+	if a.info == nil {
+		return nil
+	}
+	if err := copyToClipboard(a.info.body); err != nil {
+		a.logErr("Copy", err)
+		return nil
+	}
+	a.logf("Copied %s to clipboard", strings.ToLower(a.info.title))
+	return nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// paletteCommand is an action reachable by name from the command palette.
+type paletteCommand struct {
+	name string                              // Name matched against the typed query
+	keys string                              // Keys bound to the action, empty if none
+	run  func(*gocui.Gui, *gocui.View) error // Action to run
+}
+
+// commandPalette holds the state of the open command palette.
+type commandPalette struct {
+	query    string           // Text typed so far
+	matches  []paletteCommand // Commands matching query
+	selected int              // Index of the selected match
+	prev     string           // View focused before the palette opened
+}
+
+// paletteCommands returns the commands offered by the palette: every global
+// and pane action in the binding table, named by its description, followed
+// by the actions that have no key. Navigation and mouse bindings are left
+// out, as are the keys of overlays, which only make sense while one is open.
+func (a *App) paletteCommands() []paletteCommand {
+	var cmds []paletteCommand
+	index := make(map[string]int)
+	for _, b := range a.keys {
+		if b.view != "" && b.view != viewInstalled && b.view != viewRunning {
+			continue
+		}
+		if isNavigation(b) {
+			continue
+		}
+		if i, ok := index[b.desc]; ok {
+			cmds[i].keys += " " + keyName(b.key)
+			continue
+		}
+		run := b.handler
+		if fn, ok := a.chordActions[b.desc]; ok {
+			// Run two-key commands at once rather than waiting for the second key.
+			run = fn
+		}
+		index[b.desc] = len(cmds)
+		cmds = append(cmds, paletteCommand{name: b.desc, keys: keyName(b.key), run: run})
+	}
+	return append(cmds,
+		paletteCommand{name: "switch host", run: a.onSwitchHost},
+		paletteCommand{name: "show/hide status pane", run: a.onToggleStatusPane},
+	)
+}
+
+// isNavigation reports whether b moves a selection or is a mouse action,
+// neither of which is useful to run by name.
+func isNavigation(b binding) bool {
+	if k, ok := b.key.(gocui.Key); ok {
+		switch k {
+		case gocui.MouseLeft, gocui.MouseWheelUp, gocui.MouseWheelDown:
+			return true
+		}
+	}
+	return strings.HasPrefix(b.desc, "move ") || strings.Contains(b.desc, "page ")
+}
+
+// filterCommands returns the commands whose name fuzzily matches query.
+func filterCommands(cmds []paletteCommand, query string) []paletteCommand {
+	var out []paletteCommand
+	for _, c := range cmds {
+		if fuzzyMatch(query, c.name) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// paletteEditor edits the palette query and narrows the command list after
+// every keystroke.
+type paletteEditor struct {
+	a *App // Application whose palette is edited
+}
+
+// Edit applies the key to the query and refilters the commands.
+func (e paletteEditor) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	gocui.DefaultEditor.Edit(v, key, ch, mod)
+	p := e.a.palette
+	if p == nil {
+		return
+	}
+	if text := createInput(v); text != p.query {
+		p.query = text
+		p.matches = filterCommands(e.a.paletteCommands(), text)
+		p.selected = 0
+		e.a.drawPalette()
+	}
+}
+
+// onOpenPalette opens the command palette listing all commands.
+func (a *App) onOpenPalette(g *gocui.Gui, v *gocui.View) error {
+	prev := viewInstalled
+	if v != nil {
+		prev = v.Name()
+	}
+	a.palette = &commandPalette{matches: a.paletteCommands(), prev: prev}
+	return nil
+}
+
+// layoutPalette draws the command palette, if it is open: a query line with
+// the matching commands below it.
+func (a *App) layoutPalette(g *gocui.Gui) error {
+	if a.palette == nil {
+		for _, name := range []string{viewPaletteInput, viewPalette} {
+			if _, err := g.View(name); err == nil {
+				if err := g.DeleteView(name); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	w := maxX / 2
+	if w < 40 {
+		w = maxX - 2
+	}
+	x0, y0 := (maxX-w)/2, maxY/6
+	h := maxY*2/3 - 3
+	if h < 3 {
+		h = 3
+	}
+	v, err := g.SetView(viewPalette, x0, y0+2, x0+w, y0+2+h)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		a.drawPalette()
+	}
+	in, err := g.SetView(viewPaletteInput, x0, y0, x0+w, y0+2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		in.Title = "Command (Enter run, Esc cancel)"
+		in.Editable = true
+		in.Editor = paletteEditor{a}
+		if err := a.focusEditable(g, viewPaletteInput); err != nil {
+			return err
+		}
+	}
+	if _, err := g.SetViewOnTop(viewPalette); err != nil {
+		return err
+	}
+	_, err = g.SetViewOnTop(viewPaletteInput)
+	return err
+}
+
+// drawPalette renders the matching commands with their keys.
+func (a *App) drawPalette() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewPalette)
+		if err != nil || a.palette == nil {
+			return nil
+		}
+		p := a.palette
+		v.Clear()
+		if len(p.matches) == 0 {
+			v.Highlight = false
+			fmt.Fprintln(v, "(no matching commands)")
+			return nil
+		}
+		w, _ := v.Size()
+		for _, c := range p.matches {
+			fmt.Fprintf(v, "%s  %s\n", fitWidth(c.name, w-14), a.theme.paint(a.theme.accent, fmt.Sprintf("%10s", c.keys)))
+		}
+		v.Highlight = true
+		return showRow(v, p.selected)
+	})
+}
+
+// onPaletteUp moves the palette selection one row up.
+func (a *App) onPaletteUp(_ *gocui.Gui, _ *gocui.View) error {
+	if a.palette != nil && a.palette.selected > 0 {
+		a.palette.selected--
+		a.drawPalette()
+	}
+	return nil
+}
+
+// onPaletteDown moves the palette selection one row down.
+func (a *App) onPaletteDown(_ *gocui.Gui, _ *gocui.View) error {
+	if a.palette != nil && a.palette.selected < len(a.palette.matches)-1 {
+		a.palette.selected++
+		a.drawPalette()
+	}
+	return nil
+}
+
+// closePalette removes the palette and restores focus to the view that was
+// focused before it opened, which it returns.
+func (a *App) closePalette(g *gocui.Gui) (*gocui.View, error) {
+	p := a.palette
+	a.palette = nil
+	for _, name := range []string{viewPaletteInput, viewPalette} {
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return nil, err
+		}
+	}
+	if p == nil {
+		return nil, nil
+	}
+	return g.SetCurrentView(p.prev)
+}
+
+// onPaletteRun closes the palette and runs the selected command in the view
+// that was focused before.
+func (a *App) onPaletteRun(g *gocui.Gui, _ *gocui.View) error {
+	p := a.palette
+	if p == nil {
+		return nil
+	}
+	v, err := a.closePalette(g)
+	if err != nil || p.selected >= len(p.matches) {
+		return err
+	}
+	return p.matches[p.selected].run(g, v)
+}
+
+// onPaletteCancel closes the palette without running a command.
+func (a *App) onPaletteCancel(g *gocui.Gui, _ *gocui.View) error {
+	_, err := a.closePalette(g)
+	return err
+}
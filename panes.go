@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jroimartin/gocui"
+)
+
+// Modes of the right-hand column holding the running and tasks panes, set
+// by Config.RunningPane.
+const (
+	paneShown  = ""       // Always shown
+	paneAuto   = "auto"   // Hidden while no model is loaded
+	paneHidden = "hidden" // Always hidden
+)
+
+// Limits and step of the divider between the installed pane and the right
+// column, in percent of the screen width.
+const (
+	minSplit  = 20
+	maxSplit  = 80
+	splitStep = 5
+)
+
+// validateLayout checks the pane layout settings of c.
+func (c *Config) validateLayout() error {
+	if c.Split != 0 && (c.Split < minSplit || c.Split > maxSplit) {
+		return fmt.Errorf("split: %d is out of range (%d-%d)", c.Split, minSplit, maxSplit)
+	}
+	switch c.RunningPane {
+	case paneShown, paneAuto, paneHidden:
+		return nil
+	}
+	return fmt.Errorf("running_pane: unknown mode %q (want %q or %q)", c.RunningPane, paneAuto, paneHidden)
+}
+
+// split returns the configured width of the installed pane in percent.
+func (c *Config) split() int {
+	if c.Split == 0 {
+		return 50
+	}
+	return c.Split
+}
+
+// rightVisible reports whether the running and tasks panes are shown.
+func (a *App) rightVisible() bool {
+	switch a.config.RunningPane {
+	case paneHidden:
+		return false
+	case paneAuto:
+		return len(a.running) > 0
+	}
+	return true
+}
+
+// removeViews deletes the named views if they exist, moving focus to the
+// installed pane if one of them had it.
+func removeViews(g *gocui.Gui, names ...string) error {
+	for _, name := range names {
+		if _, err := g.View(name); err != nil {
+			continue
+		}
+		if v := g.CurrentView(); v != nil && v.Name() == name {
+			if _, err := g.SetCurrentView(viewInstalled); err != nil {
+				return err
+			}
+		}
+		if err := g.DeleteView(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resizeSplit moves the divider by delta percent within the limits and
+// saves the layout.
+func (a *App) resizeSplit(delta int) {
+	pct := a.config.split() + delta
+	if pct < minSplit || pct > maxSplit {
+		return
+	}
+	a.config.Split = pct
+	a.persistConfig()
+}
+
+// onGrowSplit widens the installed pane.
+func (a *App) onGrowSplit(_ *gocui.Gui, _ *gocui.View) error {
+	a.resizeSplit(splitStep)
+	return nil
+}
+
+// onShrinkSplit narrows the installed pane.
+func (a *App) onShrinkSplit(_ *gocui.Gui, _ *gocui.View) error {
+	a.resizeSplit(-splitStep)
+	return nil
+}
+
+// onCycleRunningPane switches the running and tasks panes between shown,
+// hidden while nothing is loaded, and hidden, and saves the choice.
+func (a *App) onCycleRunningPane(_ *gocui.Gui, _ *gocui.View) error {
+	switch a.config.RunningPane {
+	case paneShown:
+		a.config.RunningPane = paneAuto
+		a.logf("Running pane hidden while no model is loaded")
+	case paneAuto:
+		a.config.RunningPane = paneHidden
+		a.logf("Running pane hidden")
+	default:
+		a.config.RunningPane = paneShown
+		a.logf("Running pane shown")
+	}
+	a.persistConfig()
+	return nil
+}
+
+// onToggleStatusPane hides or shows the status pane and saves the choice.
+func (a *App) onToggleStatusPane(_ *gocui.Gui, _ *gocui.View) error {
+	a.config.HideStatus = !a.config.HideStatus
+	a.persistConfig()
+	return nil
+}
+
+// layoutRight lays out the running pane above the tasks pane in the column
+// from x0 to maxX, drawing them when they are (re)created.
+func (a *App) layoutRight(g *gocui.Gui, x0, maxX, bodyH int) error {
+	tasksH := a.tasksHeight(bodyH)
+	if v, err := a.setView(g, viewRunning, x0, 0, maxX-1, bodyH-1-tasksH); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = a.runningTitle()
+		v.Wrap = false
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		a.drawRunning()
+	}
+
+	if v, err := a.setView(g, viewTasks, x0, bodyH-tasksH, maxX-1, bodyH-1); err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Wrap = false
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		a.drawTasks()
+	}
+	return nil
+}
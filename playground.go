@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// chatSession is the state of the chat playground.
+type chatSession struct {
+	model     string            // Model being chatted with
+	messages  []ollama.Message  // Conversation so far, oldest first
+	reply     strings.Builder   // Reply being streamed, appended to messages when done
+	streaming bool              // Whether a reply is being streamed
+	cancel    func()            // Stops the streaming reply, nil when idle
+	format    json.RawMessage   // Format sent with every request, nil for free text
+	tools     []ollama.Tool     // Tools offered with every request, nil for none
+	toolCalls []ollama.ToolCall // Tool calls of the reply being streamed
+	notes     map[int]string    // Remarks shown after messages, keyed by message index
+}
+
+// onOpenChat opens the chat playground with the selected installed model.
+func (a *App) onOpenChat(_ *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	a.chat = &chatSession{model: m.Name, notes: make(map[int]string)}
+	return nil
+}
+
+// title returns the transcript title, naming the model and format.
+func (s *chatSession) title() string {
+	title := "Chat: " + displayName(s.model)
+	switch {
+	case len(s.format) == 0:
+	case bytes.Equal(s.format, ollama.FormatJSON):
+		title += " [format: json]"
+	default:
+		title += " [format: schema]"
+	}
+	if len(s.tools) > 0 {
+		title += fmt.Sprintf(" [tools: %d]", len(s.tools))
+	}
+	return title
+}
+
+// layoutChat draws the chat playground, if it is open: the transcript above
+// and a single-line input below.
+func (a *App) layoutChat(g *gocui.Gui) error {
+	if a.chat == nil {
+		for _, name := range []string{viewChat, viewChatInput} {
+			if _, err := g.View(name); err == nil {
+				if err := g.DeleteView(name); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	v, err := g.SetView(viewChat, 2, 1, maxX-3, maxY-7)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Wrap = true
+		v.Autoscroll = true
+		a.drawChat()
+	}
+	v.Title = a.chat.title()
+	if _, err := g.SetViewOnTop(viewChat); err != nil {
+		return err
+	}
+
+	in, err := g.SetView(viewChatInput, 2, maxY-6, maxX-3, maxY-4)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		in.Title = "Message (Enter send, /tool <result> answer a call, Ctrl+F format, Ctrl+T tools, Ctrl+N new chat, Esc stop/close)"
+		in.Editable = true
+		if err := a.focusEditable(g, viewChatInput); err != nil {
+			return err
+		}
+	}
+	_, err = g.SetViewOnTop(viewChatInput)
+	return err
+}
+
+// drawChat renders the conversation, including the reply being streamed.
+func (a *App) drawChat() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewChat)
+		if err != nil || a.chat == nil {
+			return nil
+		}
+		s := a.chat
+		v.Clear()
+		if len(s.messages) == 0 && !s.streaming {
+			fmt.Fprintln(v, "Type a message below and press Enter.")
+			return nil
+		}
+		for i, m := range s.messages {
+			a.drawChatMessage(v, m)
+			if note := s.notes[i]; note != "" {
+				fmt.Fprintln(v, note)
+			}
+			fmt.Fprintln(v)
+		}
+		if s.streaming {
+			a.drawChatMessage(v, ollama.Message{Role: ollama.RoleAssistant, Content: s.reply.String() + "▌"})
+		}
+		return nil
+	})
+}
+
+// drawChatMessage writes one message of the transcript with its role label.
+// Tool calls are shown as the raw JSON the model emitted.
+func (a *App) drawChatMessage(v *gocui.View, m ollama.Message) {
+	label := "you"
+	switch m.Role {
+	case ollama.RoleAssistant:
+		label = displayName(a.chat.model)
+	case ollama.RoleTool:
+		label = "tool " + m.ToolName
+	}
+	fmt.Fprintln(v, a.theme.paint(a.theme.accent, label+":"))
+	if m.Content != "" {
+		fmt.Fprintln(v, m.Content)
+	}
+	for _, tc := range m.ToolCalls {
+		raw, _ := json.Marshal(tc)
+		fmt.Fprintln(v, a.theme.paint(a.theme.alert, "→ tool call "+tc.Function.Name+": ")+string(raw))
+	}
+}
+
+// onChatSend sends the typed message and streams the model's reply.
+func (a *App) onChatSend(g *gocui.Gui, v *gocui.View) error {
+	s := a.chat
+	text := createInput(v)
+	if s == nil || s.streaming || text == "" {
+		return nil
+	}
+	v.Clear()
+	v.SetCursor(0, 0)
+	v.SetOrigin(0, 0)
+	s.messages = append(s.messages, s.userMessage(text))
+	s.reply.Reset()
+	s.toolCalls = nil
+	s.streaming = true
+	req := ollama.ChatRequest{
+		Model:    s.model,
+		Messages: append([]ollama.Message(nil), s.messages...),
+		Format:   s.format,
+		Tools:    s.tools,
+	}
+	ctx, finish := a.startTask("chat " + s.model)
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	a.drawChat()
+	client := a.client
+	go func() {
+		defer cancel()
+		metrics, err := client.Chat(ctx, req, func(chunk ollama.ChatResponse) error {
+			a.safeUpdate(func(g *gocui.Gui) error {
+				if a.chat == s {
+					s.reply.WriteString(chunk.Message.Content)
+					s.toolCalls = append(s.toolCalls, chunk.Message.ToolCalls...)
+					a.drawChat()
+				}
+				return nil
+			})
+			return nil
+		})
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if a.chat != s {
+				return nil
+			}
+			s.streaming, s.cancel = false, nil
+			switch {
+			case isCanceled(err):
+				s.messages = s.messages[:len(s.messages)-1]
+				a.logf("Canceled chat reply")
+			case err != nil:
+				s.messages = s.messages[:len(s.messages)-1]
+				metricErrors.Add(1)
+				a.logErr("Chat "+s.model, err)
+			default:
+				s.addReply(ollama.Message{Role: ollama.RoleAssistant, Content: s.reply.String(), ToolCalls: s.toolCalls}, metrics)
+			}
+			a.drawChat()
+			return nil
+		})
+	}()
+	return nil
+}
+
+// userMessage returns the message for text typed by the user. Text starting
+// with "/tool " answers the last tool call with the rest as its result.
+func (s *chatSession) userMessage(text string) ollama.Message {
+	result, ok := strings.CutPrefix(text, "/tool ")
+	if !ok {
+		return ollama.Message{Role: ollama.RoleUser, Content: text}
+	}
+	msg := ollama.Message{Role: ollama.RoleTool, Content: result}
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if calls := s.messages[i].ToolCalls; len(calls) > 0 {
+			msg.ToolName = calls[len(calls)-1].Function.Name
+			break
+		}
+	}
+	return msg
+}
+
+// addReply appends a completed reply to the conversation, noting its speed
+// and, when a format was requested, whether the reply is valid JSON.
+func (s *chatSession) addReply(reply ollama.Message, m ollama.Metrics) {
+	s.messages = append(s.messages, reply)
+	var notes []string
+	if tps := m.TokensPerSecond(); tps > 0 {
+		notes = append(notes, fmt.Sprintf("%d tokens, %.1f tokens/s", m.EvalCount, tps))
+	}
+	if len(reply.ToolCalls) > 0 {
+		notes = append(notes, "answer with /tool <result>")
+	}
+	if len(s.format) > 0 && len(reply.ToolCalls) == 0 {
+		var v any
+		if err := json.Unmarshal([]byte(reply.Content), &v); err != nil {
+			notes = append(notes, "✗ not valid JSON: "+err.Error())
+		} else {
+			notes = append(notes, "✓ valid JSON")
+		}
+	}
+	if len(notes) > 0 {
+		s.notes[len(s.messages)-1] = "(" + strings.Join(notes, " · ") + ")"
+	}
+}
+
+// onChatFormat asks for the reply format: "json" for any JSON, a JSON
+// schema, "@file" to read a schema from a file, or nothing for free text.
+func (a *App) onChatFormat(g *gocui.Gui, _ *gocui.View) error {
+	s := a.chat
+	if s == nil {
+		return nil
+	}
+	a.askInput(g, "Format: json, a JSON schema, @schema.json, or empty for text", string(s.format), func(g *gocui.Gui, text string) error {
+		format, err := parseChatFormat(text)
+		if err != nil {
+			a.logErr("Format", err)
+			return nil
+		}
+		if len(format) > 0 && !bytes.Equal(format, ollama.FormatJSON) && !a.requireFeature(ollama.FeatureStructuredOutput) {
+			return nil
+		}
+		s.format = format
+		return nil
+	})
+	return nil
+}
+
+// parseChatFormat converts the text entered for the format into a Format
+// value, reading "@path" from a file and checking that schemas are objects.
+func parseChatFormat(text string) (json.RawMessage, error) {
+	switch {
+	case text == "":
+		return nil, nil
+	case text == "json":
+		return ollama.FormatJSON, nil
+	case strings.HasPrefix(text, "@"):
+		data, err := os.ReadFile(strings.TrimPrefix(text, "@"))
+		if err != nil {
+			return nil, err
+		}
+		text = string(data)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(text), &schema); err != nil {
+		return nil, fmt.Errorf("schema is not a JSON object: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(text)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// onChatTools asks for the tools offered to the model: a JSON array of tool
+// or function definitions, "@file" to read them from a file, or nothing to
+// offer none.
+func (a *App) onChatTools(g *gocui.Gui, _ *gocui.View) error {
+	s := a.chat
+	if s == nil {
+		return nil
+	}
+	a.askInput(g, "Tools: JSON array of functions, @tools.json, or empty for none", "", func(g *gocui.Gui, text string) error {
+		tools, err := parseChatTools(text)
+		if err != nil {
+			a.logErr("Tools", err)
+			return nil
+		}
+		if len(tools) > 0 && !a.requireFeature(ollama.FeatureTools) {
+			return nil
+		}
+		s.tools = tools
+		a.logf("Offering %d tools to %s", len(tools), displayName(s.model))
+		return nil
+	})
+	return nil
+}
+
+// parseChatTools parses tool definitions entered for the playground. Both
+// full tools ({"type": "function", "function": {...}}) and bare function
+// definitions are accepted, as an array or a single object.
+func parseChatTools(text string) ([]ollama.Tool, error) {
+	if text == "" {
+		return nil, nil
+	}
+	if path, ok := strings.CutPrefix(text, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		text = strings.TrimSpace(string(data))
+	}
+	if !strings.HasPrefix(text, "[") {
+		text = "[" + text + "]"
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(text), &raw); err != nil {
+		return nil, fmt.Errorf("tools are not a JSON array: %w", err)
+	}
+	tools := make([]ollama.Tool, 0, len(raw))
+	for i, r := range raw {
+		var t ollama.Tool
+		if err := json.Unmarshal(r, &t); err != nil {
+			return nil, fmt.Errorf("tool %d: %w", i+1, err)
+		}
+		if t.Function.Name == "" {
+			t = ollama.Tool{}
+			if err := json.Unmarshal(r, &t.Function); err != nil {
+				return nil, fmt.Errorf("tool %d: %w", i+1, err)
+			}
+		}
+		if t.Function.Name == "" {
+			return nil, fmt.Errorf("tool %d: function name is required", i+1)
+		}
+		t.Type = "function"
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
+
+// onChatReset starts a new conversation with the same model and format.
+func (a *App) onChatReset(_ *gocui.Gui, _ *gocui.View) error {
+	s := a.chat
+	if s == nil || s.streaming {
+		return nil
+	}
+	s.messages, s.notes = nil, make(map[int]string)
+	a.drawChat()
+	return nil
+}
+
+// onChatEscape stops the reply being streamed or, when idle, closes the
+// playground.
+func (a *App) onChatEscape(g *gocui.Gui, _ *gocui.View) error {
+	s := a.chat
+	if s == nil {
+		return nil
+	}
+	if s.streaming && s.cancel != nil {
+		s.cancel()
+		return nil
+	}
+	a.chat = nil
+	for _, name := range []string{viewChat, viewChatInput} {
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	_, err := g.SetCurrentView(viewInstalled)
+	return err
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// transferProgress is the latest progress of a pull or push, with a
+// smoothed transfer rate for the current blob.
+type transferProgress struct {
+	status    string    // Current step, e.g. "pulling manifest"
+	digest    string    // Blob being transferred, if any
+	completed int64     // Bytes of the blob transferred so far
+	total     int64     // Total bytes of the blob, 0 while unknown
+	rate      float64   // Smoothed transfer rate in bytes per second
+	sampled   int64     // Bytes completed at the last rate sample
+	sampledAt time.Time // Time of the last rate sample
+}
+
+// rateInterval is the shortest interval between rate samples, so bursts of
+// progress updates do not make the rate jump around.
+const rateInterval = 500 * time.Millisecond
+
+// update records a progress response received at now.
+func (p *transferProgress) update(r ollama.ProgressResponse, now time.Time) {
+	if r.Digest != p.digest {
+		p.digest, p.rate = r.Digest, 0
+		p.sampled, p.sampledAt = r.Completed, now
+	}
+	p.status, p.completed, p.total = r.Status, r.Completed, r.Total
+	if dt := now.Sub(p.sampledAt); dt >= rateInterval {
+		rate := float64(p.completed-p.sampled) / dt.Seconds()
+		if p.rate > 0 {
+			rate = 0.3*rate + 0.7*p.rate
+		}
+		p.rate, p.sampled, p.sampledAt = rate, p.completed, now
+	}
+}
+
+// eta returns the estimated time until the blob completes, or 0 if it
+// cannot be estimated yet.
+func (p *transferProgress) eta() time.Duration {
+	if p.rate <= 0 || p.total <= p.completed {
+		return 0
+	}
+	return time.Duration(float64(p.total-p.completed) / p.rate * float64(time.Second)).Round(time.Second)
+}
+
+// progressBar draws a bar width cells wide filled to frac (0 to 1).
+func progressBar(frac float64, width int) string {
+	filled := int(frac * float64(width))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// line formats the progress as a bar with percentage, rate and ETA, or just
+// the current step while no size is known.
+func (p *transferProgress) line() string {
+	if p.total <= 0 {
+		return p.status
+	}
+	frac := float64(p.completed) / float64(p.total)
+	line := fmt.Sprintf("%s %3d%%", progressBar(frac, 20), int(frac*100))
+	if p.rate > 0 {
+		line += fmt.Sprintf("  %s/s", ollama.HumanSize(int64(p.rate)))
+	}
+	if eta := p.eta(); eta > 0 {
+		line += "  ETA " + eta.String()
+	}
+	return line
+}
+
+// trackProgress returns a progress callback that records each response on
+// the task running under ctx, for the progress overlay and tasks pane, and
+// also reports steps in the status pane like progressLogger. The task is
+// marked queued while the client waits for a transfer slot. It may be
+// called from any goroutine.
+func (a *App) trackProgress(ctx context.Context, op, name string) func(ollama.ProgressResponse) {
+	id, _ := ctx.Value(taskKey{}).(int)
+	logger := a.progressLogger(op, name)
+	return func(r ollama.ProgressResponse) {
+		logger(r)
+		now := time.Now()
+		a.safeUpdate(func(g *gocui.Gui) error {
+			t := a.taskByID(id)
+			if t == nil || !t.state.active() {
+				return nil
+			}
+			if t.progress == nil {
+				t.progress = &transferProgress{sampledAt: now}
+			}
+			t.progress.update(r, now)
+			t.state = taskRunning
+			if r.Status == "queued" {
+				t.state = taskQueued
+			}
+			a.drawProgress()
+			a.drawTasks()
+			return nil
+		})
+	}
+}
+
+// transferTasks returns the active tasks that report progress, oldest
+// first.
+func (a *App) transferTasks() []*task {
+	var transfers []*task
+	for _, t := range a.activeTasks(true) {
+		if t.progress != nil {
+			transfers = append(transfers, t)
+		}
+	}
+	return transfers
+}
+
+// layoutProgress draws the progress overlay in the bottom right corner of
+// the panes while pulls or pushes are running and the tasks pane, which
+// shows the same progress, is collapsed. It removes the overlay afterwards.
+func (a *App) layoutProgress(g *gocui.Gui) error {
+	transfers := a.transferTasks()
+	if len(transfers) == 0 || a.tasksExpanded {
+		if _, err := g.View(viewProgress); err == nil {
+			return g.DeleteView(viewProgress)
+		}
+		return nil
+	}
+
+	maxX, _ := g.Size()
+	// Sit above the tasks pane, or at the bottom of the installed pane while
+	// the right column is hidden.
+	_, y1, _, _, err := g.ViewPosition(viewTasks)
+	if err == gocui.ErrUnknownView {
+		_, _, _, y1, err = g.ViewPosition(viewInstalled)
+	}
+	if err != nil {
+		return err
+	}
+	w := 72
+	if w > maxX-2 {
+		w = maxX - 2
+	}
+	x0, y0 := maxX-w-2, y1-len(transfers)-2
+	if y0 < 0 {
+		y0 = 0
+	}
+	v, err := g.SetView(viewProgress, x0, y0, x0+w, y1-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Title = "Transfers (x cancel)"
+		v.Wrap = false
+		a.drawProgress()
+	}
+	_, err = g.SetViewOnTop(viewProgress)
+	return err
+}
+
+// drawProgress renders one row per transfer into the progress overlay.
+func (a *App) drawProgress() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewProgress)
+		if err != nil {
+			return nil
+		}
+		v.Clear()
+		w, _ := v.Size()
+		for _, t := range a.transferTasks() {
+			fmt.Fprintln(v, fitWidth(fitWidth(t.desc, 24)+"  "+t.progress.line(), w))
+		}
+		return nil
+	})
+}
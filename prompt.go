@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+)
+
+// askInput opens the text prompt with the given title and initial value.
+// onSubmit runs on the GUI goroutine with the trimmed text when Enter is
+// pressed; earlier answers can be recalled with ↑.
+func (a *App) askInput(g *gocui.Gui, title, value string, onSubmit func(*gocui.Gui, string) error) {
+	a.prompt.Ask(g, title+" (Enter ok, ↑ history, Esc cancel)", value, viewInstalled, onSubmit)
+}
+
+// layoutPrompt draws the text prompt, if one is open, centered on screen.
+func (a *App) layoutPrompt(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	w := maxX * 2 / 3
+	if w < 30 {
+		w = maxX - 2
+	}
+	x0, y0 := (maxX-w)/2, maxY/2-1
+	return a.prompt.Layout(g, x0, y0, x0+w)
+}
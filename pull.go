@@ -0,0 +1,99 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// onPull pulls the selected installed model again, fetching any newer version
+// from its registry.
+func (a *App) onPull(g *gocui.Gui, _ *gocui.View) error {
+	if a.confirmBatch(g, "Pull", a.pull) {
+		return nil
+	}
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	a.pull(m.Name)
+	return nil
+}
+
+// onPullByName asks for the name of a model and pulls it, so models can be
+// installed without leaving the application.
+func (a *App) onPullByName(g *gocui.Gui, _ *gocui.View) error {
+	a.askInput(g, "Pull model (e.g. llama3.2:3b)", "", func(g *gocui.Gui, name string) error {
+		if name == "" {
+			return nil
+		}
+		if a.isInstalled(name) {
+			a.askConfirm(g, displayName(name)+" is already installed. Pull it again?", func(*gocui.Gui) error {
+				a.pull(name)
+				return nil
+			})
+			return nil
+		}
+		a.pull(name)
+		return nil
+	})
+	return nil
+}
+
+// pull downloads the named model in a background goroutine, reporting its
+// progress in the status pane and the progress overlay. Once done the model
+// lists are refreshed, after which the pull-complete hook runs with the
+// size of the pulled model.
+func (a *App) pull(name string) {
+	a.logf("Pulling %s...", name)
+	ctx, finish := a.startTask("pull " + name)
+	client := a.client
+	go func() {
+		err := client.PullModel(ctx, name, a.trackProgress(ctx, "Pull", name))
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if isCanceled(err) {
+				a.logf("Canceled pull %s; the partial download is kept and resumes on the next pull", name)
+				return nil
+			}
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Pull "+name, err)
+				a.runHook(eventError, hookEnv{Model: name, Error: err.Error()})
+				return nil
+			}
+			metricPulls.Add(1)
+			delete(a.updates, name)
+			a.logf("Pulled %s", name)
+			a.refreshAllThen(func() {
+				a.runHook(eventPullComplete, hookEnv{Model: name, Size: a.installedSize(name)})
+			})
+			return nil
+		})
+	}()
+}
+
+// progressLogger returns a progress callback that reports each new step of
+// an operation in the status pane and, while a blob transfers, its progress
+// in quarters. It may be called from any goroutine.
+func (a *App) progressLogger(op, name string) func(ollama.ProgressResponse) {
+	last, lastPct := "", -1
+	return func(p ollama.ProgressResponse) {
+		pct := -1
+		if p.Total > 0 {
+			pct = int(p.Completed*100/p.Total) / 25 * 25
+		}
+		if p.Status == last && pct == lastPct {
+			return
+		}
+		last, lastPct = p.Status, pct
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if pct >= 0 {
+				a.logf("%s %s: %s %d%%", op, name, p.Status, pct)
+			} else {
+				a.logf("%s %s: %s", op, name, p.Status)
+			}
+			return nil
+		})
+	}
+}
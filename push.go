@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// onPush asks for confirmation and then pushes the selected installed model
+// to the registry in its name.
+func (a *App) onPush(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	name := m.Name
+	ref := ollama.ParseModelRef(name)
+	if ref.Namespace == "library" {
+		a.logf("Push needs a namespaced name such as user/%s; copy it with c first", ref.Model)
+		return nil
+	}
+	insecure := a.config.insecureRegistry(ref.Host)
+	msg := "Push " + displayName(name) + " to its registry?"
+	if insecure {
+		msg = "Push " + displayName(name) + " over an insecure connection?"
+	}
+	a.askConfirm(g, msg, func(*gocui.Gui) error {
+		a.push(name, insecure)
+		return nil
+	})
+	return nil
+}
+
+// push uploads the named model in a background goroutine, reporting its
+// progress in the status pane and the progress overlay.
+func (a *App) push(name string, insecure bool) {
+	a.logf("Pushing %s...", name)
+	ctx, finish := a.startTask("push " + name)
+	client := a.client
+	go func() {
+		err := client.PushModel(ctx, name, insecure, a.trackProgress(ctx, "Push", name))
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if isCanceled(err) {
+				a.logf("Canceled push %s", name)
+				return nil
+			}
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Push "+name, err)
+				a.runHook(eventError, hookEnv{Model: name, Error: err.Error()})
+				return nil
+			}
+			a.logf("Pushed %s", name)
+			return nil
+		})
+	}()
+}
+
+// insecureRegistry reports whether host is configured to allow insecure
+// connections. The default registry never is.
+func (c *Config) insecureRegistry(host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, h := range c.InsecureRegistries {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// configChanges lists the settings that differ between two configurations.
+func configChanges(old, cur *Config) []string {
+	var changes []string
+	if old.Host != cur.Host {
+		changes = append(changes, "host (takes effect after restart)")
+	}
+	if old.Theme != cur.Theme {
+		changes = append(changes, fmt.Sprintf("theme %q→%q", old.Theme, cur.Theme))
+	}
+	if !reflect.DeepEqual(old.KeepAlive, cur.KeepAlive) {
+		changes = append(changes, fmt.Sprintf("keep_alive (%d rules)", len(cur.KeepAlive)))
+	}
+	if old.DefaultModel != cur.DefaultModel {
+		changes = append(changes, fmt.Sprintf("default_model %q→%q", old.DefaultModel, cur.DefaultModel))
+	}
+	if old.CompactRunning != cur.CompactRunning {
+		changes = append(changes, fmt.Sprintf("compact_running %v→%v", old.CompactRunning, cur.CompactRunning))
+	}
+	if old.HideSize != cur.HideSize {
+		changes = append(changes, fmt.Sprintf("hide_size %v→%v", old.HideSize, cur.HideSize))
+	}
+	if !reflect.DeepEqual(old.AgeBuckets, cur.AgeBuckets) {
+		changes = append(changes, fmt.Sprintf("age_buckets (%d buckets)", len(cur.AgeBuckets)))
+	}
+	if old.IdleTimeout != cur.IdleTimeout {
+		changes = append(changes, "idle_timeout (takes effect after restart)")
+	}
+	if old.MaxTransfers != cur.MaxTransfers {
+		changes = append(changes, "max_transfers (takes effect after restart)")
+	}
+	if old.Split != cur.Split || old.RunningPane != cur.RunningPane || old.HideStatus != cur.HideStatus {
+		changes = append(changes, "pane layout")
+	}
+	if old.RefreshRunning != cur.RefreshRunning || old.RefreshInstalled != cur.RefreshInstalled {
+		changes = append(changes, "auto-refresh intervals")
+	}
+	if old.Keymap != cur.Keymap {
+		changes = append(changes, "keymap")
+	}
+	if !reflect.DeepEqual(old.Keybindings, cur.Keybindings) {
+		changes = append(changes, "keybindings")
+	}
+	if old.DisableMouse != cur.DisableMouse {
+		changes = append(changes, "disable_mouse (takes effect after restart)")
+	}
+	if old.Backend != cur.Backend {
+		changes = append(changes, "backend (takes effect after restart)")
+	}
+	if old.Proxy != cur.Proxy {
+		changes = append(changes, "proxy (takes effect after restart)")
+	}
+	if !reflect.DeepEqual(old.TLS, cur.TLS) {
+		changes = append(changes, "tls (takes effect after restart)")
+	}
+	if old.Token != cur.Token || !reflect.DeepEqual(old.BasicAuth, cur.BasicAuth) || !reflect.DeepEqual(old.Headers, cur.Headers) {
+		changes = append(changes, "authentication and headers (take effect after restart)")
+	}
+	if !reflect.DeepEqual(old.InsecureRegistries, cur.InsecureRegistries) {
+		changes = append(changes, fmt.Sprintf("insecure_registries (%d hosts)", len(cur.InsecureRegistries)))
+	}
+	if !reflect.DeepEqual(old.Hooks, cur.Hooks) {
+		changes = append(changes, fmt.Sprintf("hooks (%d events)", len(cur.Hooks)))
+	}
+	return changes
+}
+
+// applyConfig makes cfg the active configuration and applies its theme to
+// the GUI and all existing views. The running pane's detail level is only
+// reset if compact_running changed, so a toggle with 'v' survives a reload.
+func (a *App) applyConfig(g *gocui.Gui, cfg *Config) {
+	if a.config.CompactRunning != cfg.CompactRunning {
+		a.runningDetailed = !cfg.CompactRunning && a.supports(ollama.FeatureRunningDetails)
+	}
+	a.config = cfg
+	a.theme = themeByName(cfg.Theme)
+	if a.forceMono {
+		a.theme = themeMono
+	}
+	a.applyTheme()
+	for _, name := range []string{viewInstalled, viewRunning} {
+		if v, err := g.View(name); err == nil {
+			v.SelFgColor = a.theme.rowFg
+			v.SelBgColor = a.theme.rowBg
+		}
+	}
+	a.drawInstalled()
+	a.drawRunning()
+}
+
+// onReloadConfig re-reads the configuration file and applies it, including
+// changed key bindings. An invalid file leaves the current configuration in
+// place and reports the error.
+func (a *App) onReloadConfig(g *gocui.Gui, _ *gocui.View) error {
+	cfg, err := loadConfig(a.configPath)
+	a.configErr = err
+	if err != nil {
+		a.logErr("Config reload failed, keeping previous config", err)
+		return nil
+	}
+	changes := configChanges(a.config, cfg)
+	rebind := a.config.Keymap != cfg.Keymap || !reflect.DeepEqual(a.config.Keybindings, cfg.Keybindings)
+	a.applyConfig(g, cfg)
+	if rebind {
+		if err := a.rebindKeys(g); err != nil {
+			a.logf("Keybindings: %v; keeping the previous keys", err)
+		}
+	}
+	if len(changes) == 0 {
+		a.logf("Config reloaded: no changes")
+		return nil
+	}
+	a.logf("Config reloaded: %s", strings.Join(changes, ", "))
+	return nil
+}
+
+// persistConfig saves the active configuration after a preference changed in
+// the UI. A config file that failed to load is left untouched so that the
+// user's edits are not overwritten.
+func (a *App) persistConfig() {
+	if a.configErr != nil {
+		a.logf("Not saving config: fix %v first", a.configErr)
+		return
+	}
+	if err := saveConfig(a.configPath, a.config); err != nil {
+		a.logErr("Save config", err)
+	}
+}
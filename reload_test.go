@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jroimartin/gocui"
+)
+
+// reloadApp returns an app with its default keys registered with g and a
+// config file at a temporary path.
+func reloadApp(t *testing.T, g *gocui.Gui) *App {
+	t.Helper()
+	a := newApp("http://localhost:11434")
+	a.configPath = filepath.Join(t.TempDir(), "config.json")
+	if err := a.registerKeys(g, a.bindings()); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// reload writes config to the app's config file and reloads it.
+func reload(t *testing.T, a *App, g *gocui.Gui, config string) {
+	t.Helper()
+	if err := os.WriteFile(a.configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.onReloadConfig(g, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// keysFor returns the keys bound to the action desc in view.
+func keysFor(a *App, view, desc string) []string {
+	var keys []string
+	for _, b := range a.keys {
+		if b.view == view && b.desc == desc {
+			keys = append(keys, keyName(b.key))
+		}
+	}
+	return keys
+}
+
+// TestReloadRebindsKeys checks that reloading the config applies changed
+// keybindings, and keeps the previous ones when the new ones conflict.
+func TestReloadRebindsKeys(t *testing.T) {
+	g := &gocui.Gui{}
+	a := reloadApp(t, g)
+
+	reload(t, a, g, `{"keybindings": {"refresh": "F9"}}`)
+	if got := strings.Join(keysFor(a, "", "refresh"), " "); got != keyName(gocui.KeyF9) {
+		t.Errorf("refresh bound to %q after reload, want only F9", got)
+	}
+
+	reload(t, a, g, `{"keybindings": {"refresh": "q"}}`)
+	if got := strings.Join(keysFor(a, "", "refresh"), " "); got != keyName(gocui.KeyF9) {
+		t.Errorf("refresh bound to %q after a conflicting reload, want F9 kept", got)
+	}
+	if status := strings.Join(a.status(), "\n"); !strings.Contains(status, "keeping the previous keys") {
+		t.Errorf("status = %q, want the conflict reported", status)
+	}
+
+	reload(t, a, g, `{}`)
+	if got := strings.Join(keysFor(a, "", "refresh"), " "); got != "r "+keyName(gocui.KeyCtrlR) {
+		t.Errorf("refresh bound to %q after removing the keybindings, want the defaults", got)
+	}
+}
+
+// TestReloadKeepsRunningDetail checks that a reload only resets the running
+// pane's detail level when compact_running changed.
+func TestReloadKeepsRunningDetail(t *testing.T) {
+	g := &gocui.Gui{}
+	a := reloadApp(t, g)
+	a.runningDetailed = false
+
+	reload(t, a, g, `{"theme": "light"}`)
+	if a.runningDetailed {
+		t.Error("reload without compact_running changes reset the running detail toggle")
+	}
+	reload(t, a, g, `{"theme": "light", "compact_running": true}`)
+	reload(t, a, g, `{"theme": "light", "compact_running": false}`)
+	if !a.runningDetailed {
+		t.Error("turning compact_running off did not show detailed running rows")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"olazyllama/internal/ollama"
+)
+
+// refreshBackoff is the delay before each retry of a refresh that failed
+// with a transient network error.
+var refreshBackoff = []time.Duration{500 * time.Millisecond, 1 * time.Second, 2 * time.Second}
+
+// isTransient reports whether err looks like a temporary network failure,
+// such as a refused connection or failed DNS lookup after the machine wakes
+// from sleep, that is worth retrying.
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ollama.ErrServerUnreachable) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// fetchWithRetry calls fetch, retrying with backoff while either list fails
+// with a transient error. It returns the result of the last attempt. The
+// client's own per-request retries are turned off for these calls, so a
+// dead server is reported after the backoff here rather than a multiple of
+// it.
+func fetchWithRetry(ctx context.Context, client ollama.API) (installed, running []ollama.Model, installedErr, runningErr error) {
+	ctx = ollama.WithoutRetry(ctx)
+	for i := 0; ; i++ {
+		installed, running, installedErr, runningErr = fetch(ctx, client)
+		if !isTransient(installedErr) && !isTransient(runningErr) {
+			return
+		}
+		if i == len(refreshBackoff) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(refreshBackoff[i]):
+		}
+	}
+}
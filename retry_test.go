@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"olazyllama/internal/ollama"
+)
+
+// flakyClient is a mock server that fails the model list calls with a
+// connection error a given number of times before answering.
+type flakyClient struct {
+	*ollama.MockClient
+	mu       sync.Mutex
+	failures int // Remaining calls to fail
+	calls    int // List calls made
+}
+
+// fail counts a list call and reports the error it should fail with.
+func (c *flakyClient) fail() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.failures > 0 {
+		c.failures--
+		return fmt.Errorf("%w: dial tcp: connection refused", ollama.ErrServerUnreachable)
+	}
+	return nil
+}
+
+func (c *flakyClient) ListLocalModels(ctx context.Context) ([]ollama.Model, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	return c.MockClient.ListLocalModels(ctx)
+}
+
+func (c *flakyClient) ListRunning(ctx context.Context) ([]ollama.Model, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	return c.MockClient.ListRunning(ctx)
+}
+
+// newFlakyApp returns an app talking to a flaky server with two installed
+// models, one of them running, and shortens the refresh backoff.
+func newFlakyApp(t *testing.T, failures int) (*App, *flakyClient) {
+	t.Helper()
+	saved := refreshBackoff
+	refreshBackoff = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { refreshBackoff = saved })
+
+	c := &flakyClient{
+		MockClient: &ollama.MockClient{
+			Installed: models("llama3.2:latest", "qwen2.5:7b"),
+			Running:   models("llama3.2:latest"),
+		},
+		failures: failures,
+	}
+	a := newApp("http://localhost:11434")
+	a.client = c
+	return a, c
+}
+
+// refresh runs one refresh synchronously, as refreshAll does in the
+// background.
+func refresh(a *App) {
+	gen := a.hostGen
+	installed, running, err1, err2 := fetchWithRetry(context.Background(), a.client)
+	a.applyRefresh(refreshResult{gen: gen, installed: installed, running: running, installedErr: err1, runningErr: err2})
+}
+
+// TestRefreshRetriesTransientFailure checks that a refresh failing briefly,
+// as after the machine wakes from sleep, is retried and fills the panes
+// without reporting an error.
+func TestRefreshRetriesTransientFailure(t *testing.T) {
+	a, c := newFlakyApp(t, 2)
+	refresh(a)
+	if a.offline || a.installedErr != nil || a.runningErr != nil {
+		t.Fatalf("offline=%v installedErr=%v runningErr=%v, want a recovered refresh", a.offline, a.installedErr, a.runningErr)
+	}
+	if len(a.installed) != 2 || len(a.running) != 1 {
+		t.Errorf("got %d installed and %d running models, want 2 and 1", len(a.installed), len(a.running))
+	}
+	if c.calls != 4 {
+		t.Errorf("made %d list calls, want 4 (one failed attempt, then both lists)", c.calls)
+	}
+	status := strings.Join(a.status(), "\n")
+	if !strings.HasSuffix(status, "Refreshed") || strings.Contains(status, "cannot reach") {
+		t.Errorf("status = %q, want only a successful refresh", status)
+	}
+}
+
+// TestRefreshReconnects checks that a refresh failing on every retry marks
+// the server offline, and that the next successful refresh repopulates the
+// panes and reports the reconnect.
+func TestRefreshReconnects(t *testing.T) {
+	a, c := newFlakyApp(t, 2*(len(refreshBackoff)+1))
+	refresh(a)
+	if !a.offline {
+		t.Fatal("offline = false after every attempt failed")
+	}
+	if a.installedErr == nil || len(a.installed) != 0 {
+		t.Fatalf("installedErr=%v with %d models, want an error and none", a.installedErr, len(a.installed))
+	}
+	if status := strings.Join(a.status(), "\n"); !strings.Contains(status, "cannot reach Ollama") {
+		t.Errorf("status = %q, want the unreachable server reported", status)
+	}
+	if want := 2 * (len(refreshBackoff) + 1); c.calls != want {
+		t.Errorf("made %d list calls, want %d (no retries besides the backoff)", c.calls, want)
+	}
+
+	refresh(a)
+	if a.offline || a.installedErr != nil || a.runningErr != nil {
+		t.Fatalf("offline=%v installedErr=%v runningErr=%v after the server came back", a.offline, a.installedErr, a.runningErr)
+	}
+	if len(a.installed) != 2 || len(a.running) != 1 {
+		t.Errorf("got %d installed and %d running models, want 2 and 1", len(a.installed), len(a.running))
+	}
+	lines := a.status()
+	if got, want := lines[len(lines)-1], "Reconnected to http://localhost:11434"; got != want {
+		t.Errorf("last status %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// drawRunning updates the running models view with currently active models.
+// In detailed mode each row also shows size, VRAM usage, processor and expiry.
+// Models missing from the installed list (e.g. loaded by digest or just
+// deleted) are marked "(not installed)".
+func (a *App) drawRunning() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewRunning)
+		if err != nil {
+			return nil
+		}
+		v.Clear()
+		if !a.loaded {
+			v.Highlight = false
+			fmt.Fprintln(v, "Loading models...")
+			return nil
+		}
+		if a.runningErr != nil {
+			v.Highlight = false
+			a.drawPaneError(v, "running models", a.runningErr)
+			return nil
+		}
+		if len(a.running) == 0 {
+			v.Highlight = false
+			fmt.Fprintln(v, "(nothing running)")
+			return nil
+		}
+		now := time.Now()
+		header := 0
+		if a.runningDetailed {
+			fmt.Fprintln(v, runningHeader())
+			header = 1
+		}
+		for _, m := range a.running {
+			line := displayName(m.Name)
+			if a.runningDetailed {
+				line = runningLine(m, now)
+			}
+			if a.installedErr == nil && !a.isInstalled(m.Name) {
+				line += " " + a.theme.paint(a.theme.alert, "(not installed)")
+			}
+			fmt.Fprintln(v, line)
+		}
+		a.clampRunningSelection()
+		v.Highlight = g.CurrentView() == v
+		return showRow(v, a.runningSelected+header)
+	})
+}
+
+// runningRowFormat is the column layout of detailed running-model rows.
+// The name column is padded separately with fitWidth.
+const runningRowFormat = "%s  %10s  %10s  %-16s  %s"
+
+// runningHeader returns the column header for detailed running-model rows.
+func runningHeader() string {
+	return fmt.Sprintf(runningRowFormat, fitWidth("NAME", 30), "SIZE", "VRAM", "PROCESSOR", "UNTIL")
+}
+
+// runningLine formats a detailed row for a running model relative to now.
+func runningLine(m ollama.Model, now time.Time) string {
+	return fmt.Sprintf(runningRowFormat,
+		fitWidth(displayName(m.Name), 30), ollama.HumanSize(m.Size), ollama.HumanSize(m.SizeVRAM),
+		m.Processor(), ollama.HumanUntil(m.ExpiresAt, now))
+}
+
+// onToggleRunningDetail switches the running pane between compact and
+// detailed rows and saves the preference.
+func (a *App) onToggleRunningDetail(_ *gocui.Gui, _ *gocui.View) error {
+	if !a.requireFeature(ollama.FeatureRunningDetails) {
+		return nil
+	}
+	a.runningDetailed = !a.runningDetailed
+	a.config.CompactRunning = !a.runningDetailed
+	a.drawRunning()
+	a.persistConfig()
+	return nil
+}
+
+// clampRunningSelection keeps the running selection within the list bounds.
+func (a *App) clampRunningSelection() {
+	if a.runningSelected >= len(a.running) {
+		a.runningSelected = len(a.running) - 1
+	}
+	if a.runningSelected < 0 {
+		a.runningSelected = 0
+	}
+}
+
+// selectedRunning returns the selected running model, or nil if none are loaded.
+func (a *App) selectedRunning() *ollama.Model {
+	if len(a.running) == 0 {
+		return nil
+	}
+	a.clampRunningSelection()
+	return &a.running[a.runningSelected]
+}
+
+// onRunningUp moves the running selection one row up.
+func (a *App) onRunningUp(_ *gocui.Gui, _ *gocui.View) error {
+	if a.runningSelected > 0 {
+		a.runningSelected--
+		a.drawRunning()
+	}
+	return nil
+}
+
+// onRunningDown moves the running selection one row down.
+func (a *App) onRunningDown(_ *gocui.Gui, _ *gocui.View) error {
+	if a.runningSelected < len(a.running)-1 {
+		a.runningSelected++
+		a.drawRunning()
+	}
+	return nil
+}
+
+// onFocusNext cycles focus through the installed, running, tasks (while
+// expanded) and status panes, skipping hidden ones.
+// The focused pane's frame and title are highlighted, and the key legend
+// follows it. It does nothing while an overlay or form has focus.
+func (a *App) onFocusNext(g *gocui.Gui, v *gocui.View) error {
+	return a.focusStep(g, v, 1)
+}
+
+// onFocusPrev cycles focus through the panes in the opposite direction to
+// onFocusNext.
+func (a *App) onFocusPrev(g *gocui.Gui, v *gocui.View) error {
+	return a.focusStep(g, v, -1)
+}
+
+// focusStep moves focus dir steps through the pane cycle from v.
+func (a *App) focusStep(g *gocui.Gui, v *gocui.View, dir int) error {
+	if v == nil {
+		return nil
+	}
+	cycle := []string{viewInstalled, viewRunning}
+	if a.tasksExpanded {
+		cycle = append(cycle, viewTasks)
+	}
+	cycle = append(cycle, viewStatus)
+	cur := -1
+	for i, name := range cycle {
+		if name == v.Name() {
+			cur = i
+		}
+	}
+	if cur < 0 {
+		return nil
+	}
+	next := viewInstalled
+	for i := 1; i < len(cycle); i++ {
+		name := cycle[(cur+dir*i+len(cycle))%len(cycle)]
+		if _, err := g.View(name); err == nil {
+			next = name
+			break
+		}
+	}
+	if a.zoomed != "" {
+		a.zoomed = next
+	}
+	if _, err := g.SetCurrentView(next); err != nil {
+		return err
+	}
+	a.drawRunning()
+	a.drawTasks()
+	return nil
+}
+
+// onUnload evicts the selected running model from memory.
+func (a *App) onUnload(_ *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedRunning()
+	if m == nil {
+		return nil
+	}
+	name := m.Name
+	a.logf("Unloading %s...", name)
+	ctx, finish := a.startTask("unload " + name)
+	client := a.client
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		err := client.Unload(ctx, name)
+		finish(err)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if isCanceled(err) {
+				a.logf("Canceled unload %s", name)
+				return nil
+			}
+			if err != nil {
+				metricErrors.Add(1)
+				a.logErr("Unload "+name, err)
+				a.runHook(eventError, hookEnv{Model: name, Error: err.Error()})
+				return nil
+			}
+			a.logf("Unloaded %s", name)
+			a.refreshAll()
+			return nil
+		})
+	}()
+	return nil
+}
+
+// formatRuntime renders the runtime state of a running model: memory
+// placement, context length and time until it is unloaded.
+func formatRuntime(m ollama.Model, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Model:          %s\n", displayName(m.Name))
+	fmt.Fprintf(&b, "Size in memory: %s\n", ollama.HumanSize(m.Size))
+	fmt.Fprintf(&b, "VRAM:           %s\n", ollama.HumanSize(m.SizeVRAM))
+	if m.Size > 0 {
+		fmt.Fprintf(&b, "RAM (CPU):      %s\n", ollama.HumanSize(m.Size-m.SizeVRAM))
+	}
+	placement := "CPU only"
+	switch {
+	case m.SizeVRAM > 0 && m.SizeVRAM >= m.Size:
+		placement = "fully on GPU"
+	case m.SizeVRAM > 0:
+		placement = "split between GPU and CPU"
+	}
+	fmt.Fprintf(&b, "Processor:      %s (%s)\n", m.Processor(), placement)
+	if m.ContextLength > 0 {
+		fmt.Fprintf(&b, "Context length: %d tokens\n", m.ContextLength)
+	}
+	if !m.ExpiresAt.IsZero() {
+		fmt.Fprintf(&b, "Unloads in:     %s (at %s)\n", ollama.HumanUntil(m.ExpiresAt, now), m.ExpiresAt.Local().Format("15:04:05"))
+	}
+	return b.String()
+}
+
+// onShowRuntime opens an overlay with the runtime state of the selected running model.
+func (a *App) onShowRuntime(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedRunning()
+	if m == nil {
+		return nil
+	}
+	return a.showInfo(g, "Runtime: "+displayName(m.Name), formatRuntime(*m, time.Now()))
+}
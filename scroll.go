@@ -0,0 +1,43 @@
+package main
+
+import "github.com/jroimartin/gocui"
+
+// pageStep returns how many rows a page (or half a page) of v spans, at
+// least one.
+func pageStep(v *gocui.View, half bool) int {
+	_, h := v.Size()
+	if half {
+		h /= 2
+	}
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// pager returns a handler moving a selection by dir pages (or half pages)
+// of the focused view with move. The list view's drawing keeps the new
+// selection visible.
+func pager(move func(n int), dir int, half bool) func(*gocui.Gui, *gocui.View) error {
+	return func(_ *gocui.Gui, v *gocui.View) error {
+		if v == nil {
+			return nil
+		}
+		move(dir * pageStep(v, half))
+		return nil
+	}
+}
+
+// moveInstalled moves the installed selection by n rows, stopping at either end.
+func (a *App) moveInstalled(n int) {
+	a.selected += n
+	a.clampSelection()
+	a.drawInstalled()
+}
+
+// moveRunning moves the running selection by n rows, stopping at either end.
+func (a *App) moveRunning(n int) {
+	a.runningSelected += n
+	a.clampRunningSelection()
+	a.drawRunning()
+}
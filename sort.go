@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// sortMode is an ordering of the installed list cycled with 's'.
+type sortMode int
+
+// Sort modes in cycling order. sortNone keeps the server's order, or by name
+// within each family when grouped.
+const (
+	sortNone sortMode = iota
+	sortNameAsc
+	sortNameDesc
+	sortSizeDesc
+	sortSizeAsc
+	sortModifiedDesc
+	sortModifiedAsc
+	sortModes // Number of sort modes
+)
+
+// String returns the label shown in the installed pane title.
+func (s sortMode) String() string {
+	switch s {
+	case sortNameAsc:
+		return "name ↑"
+	case sortNameDesc:
+		return "name ↓"
+	case sortSizeDesc:
+		return "size ↓"
+	case sortSizeAsc:
+		return "size ↑"
+	case sortModifiedDesc:
+		return "modified ↓"
+	case sortModifiedAsc:
+		return "modified ↑"
+	}
+	return ""
+}
+
+// less reports whether mi sorts before mj in this mode. Ties, and every pair
+// in sortNone, report false so a stable sort keeps the previous order.
+func (s sortMode) less(mi, mj ollama.Model) bool {
+	switch s {
+	case sortNameAsc:
+		return mi.Name < mj.Name
+	case sortNameDesc:
+		return mi.Name > mj.Name
+	case sortSizeDesc:
+		return mi.Size > mj.Size
+	case sortSizeAsc:
+		return mi.Size < mj.Size
+	case sortModifiedDesc:
+		return mi.Modified.After(mj.Modified)
+	case sortModifiedAsc:
+		return mi.Modified.Before(mj.Modified)
+	}
+	return false
+}
+
+// onCycleSort switches the installed list to the next sort mode.
+func (a *App) onCycleSort(g *gocui.Gui, _ *gocui.View) error {
+	a.sortMode = (a.sortMode + 1) % sortModes
+	return a.reorderInstalled(g)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// spinnerFrames are the frames of the loading spinner shown in pane titles.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is how long each spinner frame is shown.
+const spinnerInterval = 100 * time.Millisecond
+
+// spinner returns the spinner frame to show at now.
+func spinner(now time.Time) string {
+	return spinnerFrames[now.UnixMilli()/spinnerInterval.Milliseconds()%int64(len(spinnerFrames))]
+}
+
+// loadingSuffix returns a spinner to append to a pane title while loading,
+// or "" when nothing is in flight.
+func loadingSuffix(loading int32) string {
+	if loading <= 0 {
+		return ""
+	}
+	return " " + spinner(time.Now())
+}
+
+// runningTitle returns the running pane title.
+func (a *App) runningTitle() string {
+	return "Running (ollama ps)" + loadingSuffix(a.loadingRunning.Load())
+}
+
+// updatePaneTitles applies the installed and running pane titles, which
+// carry the loading spinner.
+func (a *App) updatePaneTitles(g *gocui.Gui) {
+	if v, err := g.View(viewInstalled); err == nil {
+		v.Title = a.installedTitle()
+	}
+	if v, err := g.View(viewRunning); err == nil {
+		v.Title = a.runningTitle()
+	}
+}
+
+// monitorSpinner animates the loading spinners in the pane titles for the
+// lifetime of the program, updating the titles once more after loading
+// ends to remove them.
+func (a *App) monitorSpinner() {
+	go func() {
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		wasLoading := false
+		for range ticker.C {
+			loading := a.loadingInstalled.Load() > 0 || a.loadingRunning.Load() > 0
+			if loading || wasLoading {
+				a.safeUpdate(func(g *gocui.Gui) error {
+					a.updatePaneTitles(g)
+					return nil
+				})
+			}
+			wasLoading = loading
+		}
+	}()
+}
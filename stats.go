@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// statsConcurrency is how many model details the stats overlay fetches at
+// once.
+const statsConcurrency = 4
+
+// formatStats renders model statistics as the body of the stats overlay,
+// with the longest context window among the fetched model details.
+func formatStats(st ollama.ModelStats, infos map[string]*ollama.ModelInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Models:        %d\n", st.Count)
+	fmt.Fprintf(&b, "Total size:    %s\n", ollama.HumanSize(st.TotalSize))
+	fmt.Fprintf(&b, "Average size:  %s\n", ollama.HumanSize(st.AverageSize))
+	if st.Largest != nil {
+		fmt.Fprintf(&b, "Largest:       %s (%s)\n", displayName(st.Largest.Name), ollama.HumanSize(st.Largest.Size))
+	}
+	var longest string
+	var window int
+	for name, info := range infos {
+		if n := info.ContextLength(); n > window || n == window && n > 0 && name < longest {
+			longest, window = name, n
+		}
+	}
+	if window > 0 {
+		fmt.Fprintf(&b, "Longest context: %d tokens (%s)\n", window, displayName(longest))
+	}
+	if len(st.Families) > 0 {
+		b.WriteString("\nBy family:\n")
+		for _, name := range st.FamilyNames() {
+			fmt.Fprintf(&b, "  %-20s %d\n", name, st.Families[name])
+		}
+	}
+	return b.String()
+}
+
+// onShowStats fetches the details of the installed models in a background
+// goroutine and opens an overlay summarizing them once they arrive. Models
+// whose details fail to load are still counted from the model list.
+func (a *App) onShowStats(_ *gocui.Gui, _ *gocui.View) error {
+	installed := append([]ollama.Model(nil), a.installed...)
+	names := make([]string, len(installed))
+	for i, m := range installed {
+		names[i] = m.Name
+	}
+	a.logf("Loading statistics...")
+	client := a.client
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		infos, errs := client.ShowModels(ctx, names, statsConcurrency)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if len(errs) > 0 {
+				a.logErr(fmt.Sprintf("Statistics: %d of %d models lack details", len(errs), len(names)), errs[0])
+			}
+			return a.showInfo(g, "Installed model statistics", formatStats(ollama.Stats(installed), infos))
+		})
+	}()
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"olazyllama/internal/ollama"
+)
+
+// TestFormatStatsLongestContext checks that the stats overlay names the
+// model with the longest context window among those whose details loaded,
+// and leaves the line out when none report one.
+func TestFormatStatsLongestContext(t *testing.T) {
+	info := func(window float64) *ollama.ModelInfo {
+		return &ollama.ModelInfo{ModelInfo: map[string]any{
+			"general.architecture": "llama",
+			"llama.context_length": window,
+		}}
+	}
+	st := ollama.Stats(models("llama3.2:latest", "qwen2.5:7b", "gemma3:4b"))
+
+	got := formatStats(st, map[string]*ollama.ModelInfo{
+		"llama3.2:latest": info(131072),
+		"qwen2.5:7b":      info(32768),
+		"gemma3:4b":       info(131072),
+	})
+	if want := "Longest context: 131072 tokens (gemma3:4b)\n"; !strings.Contains(got, want) {
+		t.Errorf("formatStats =\n%s\nwant it to contain %q", got, want)
+	}
+
+	got = formatStats(st, map[string]*ollama.ModelInfo{"qwen2.5:7b": {}})
+	if strings.Contains(got, "Longest context") {
+		t.Errorf("formatStats =\n%s\nwant no context line without context lengths", got)
+	}
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// maxStatusHistory is the number of status messages kept for the status
+// log; the status pane shows the last statusPaneLines of them.
+const (
+	maxStatusHistory = 5000
+	statusPaneLines  = 5
+)
+
+// statusEntry is one status message with the time it was logged.
+type statusEntry struct {
+	at   time.Time // When the message was logged
+	text string    // Message text
+}
+
+// statusLog holds the state of the status log overlay.
+type statusLog struct {
+	selected int        // Index into the history of the highlighted row
+	follow   bool       // Whether the selection tracks the newest message
+	query    string     // Search text, empty when not searching
+	win      listWindow // Rows currently shown
+}
+
+// statusEntries returns a copy of the whole status history, oldest first.
+func (a *App) statusEntries() []statusEntry {
+	a.statusMu.Lock()
+	defer a.statusMu.Unlock()
+	return append([]statusEntry(nil), a.statusHistory...)
+}
+
+// statusLogLine formats e as a row of the status log, accenting matches of
+// query.
+func (a *App) statusLogLine(e statusEntry, query string) string {
+	text := e.text
+	if query != "" {
+		// Lowercasing keeps byte offsets for ASCII, which covers nearly all
+		// status text; other matches are left unaccented.
+		i := strings.Index(strings.ToLower(text), strings.ToLower(query))
+		if j := i + len(query); i >= 0 && j <= len(text) && strings.EqualFold(text[i:j], query) {
+			text = text[:i] + a.theme.paint(a.theme.accent, text[i:j]) + text[j:]
+		}
+	}
+	return e.at.Format("15:04:05") + "  " + text
+}
+
+// statusLogTitle returns the title of the status log overlay, with the
+// search and the position of the highlighted match.
+func (a *App) statusLogTitle(entries []statusEntry) string {
+	title := fmt.Sprintf("Status log: %d messages (/ search, c copy, Esc close)", len(entries))
+	s := a.statusLog
+	if s == nil || s.query == "" {
+		return title
+	}
+	n, pos := 0, 0
+	for i, e := range entries {
+		if matchesStatus(e, s.query) {
+			n++
+			if i <= s.selected {
+				pos = n
+			}
+		}
+	}
+	return fmt.Sprintf("%s  /%s %d of %d (n/N next/prev)", title, s.query, pos, n)
+}
+
+// matchesStatus reports whether e contains query, ignoring case.
+func matchesStatus(e statusEntry, query string) bool {
+	return strings.Contains(strings.ToLower(e.text), strings.ToLower(query))
+}
+
+// onShowStatusLog opens the status log overlay on the newest message.
+func (a *App) onShowStatusLog(_ *gocui.Gui, _ *gocui.View) error {
+	a.statusLog = &statusLog{follow: true}
+	return nil
+}
+
+// layoutStatusLog draws the status log overlay, if it is open, over the
+// full height of the screen.
+func (a *App) layoutStatusLog(g *gocui.Gui) error {
+	if a.statusLog == nil {
+		if _, err := g.View(viewStatusLog); err == nil {
+			return g.DeleteView(viewStatusLog)
+		}
+		return nil
+	}
+
+	maxX, maxY := g.Size()
+	v, err := g.SetView(viewStatusLog, 0, 0, maxX-1, maxY-2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Wrap = false
+		v.SelFgColor = a.theme.rowFg
+		v.SelBgColor = a.theme.rowBg
+		a.drawStatusLog()
+		if _, err := g.SetCurrentView(viewStatusLog); err != nil {
+			return err
+		}
+	} else if a.statusLog.win.resized(v) {
+		a.drawStatusLog()
+	}
+	_, err = g.SetViewOnTop(viewStatusLog)
+	return err
+}
+
+// drawStatusLog renders the visible part of the status history into the
+// status log overlay, keeping the highlighted row in view.
+func (a *App) drawStatusLog() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewStatusLog)
+		if err != nil || a.statusLog == nil {
+			return nil
+		}
+		s := a.statusLog
+		entries := a.statusEntries()
+		if s.follow || s.selected >= len(entries) {
+			s.selected = len(entries) - 1
+		}
+		if s.selected < 0 {
+			s.selected = 0
+		}
+		v.Title = a.statusLogTitle(entries)
+		v.Clear()
+		if len(entries) == 0 {
+			v.Highlight = false
+			fmt.Fprintln(v, "(no messages yet)")
+			return nil
+		}
+		v.Highlight = true
+		var buf bytes.Buffer
+		return s.win.draw(v, &buf, len(entries), s.selected, func(buf *bytes.Buffer, i int) {
+			buf.WriteString(a.statusLogLine(entries[i], s.query))
+		})
+	})
+}
+
+// moveStatusLog moves the status log selection by n rows, stopping at either
+// end. The selection follows new messages while it is on the newest one.
+func (a *App) moveStatusLog(n int) {
+	s := a.statusLog
+	if s == nil {
+		return
+	}
+	last := len(a.statusEntries()) - 1
+	s.selected += n
+	if s.selected > last {
+		s.selected = last
+	}
+	if s.selected < 0 {
+		s.selected = 0
+	}
+	s.follow = s.selected == last
+	a.drawStatusLog()
+}
+
+// onStatusLogUp moves the status log selection one row up.
+func (a *App) onStatusLogUp(_ *gocui.Gui, _ *gocui.View) error {
+	a.moveStatusLog(-1)
+	return nil
+}
+
+// onStatusLogDown moves the status log selection one row down.
+func (a *App) onStatusLogDown(_ *gocui.Gui, _ *gocui.View) error {
+	a.moveStatusLog(1)
+	return nil
+}
+
+// onStatusLogTop moves the status log selection to the oldest message.
+func (a *App) onStatusLogTop(_ *gocui.Gui, _ *gocui.View) error {
+	a.moveStatusLog(-maxStatusHistory)
+	return nil
+}
+
+// onStatusLogBottom moves the status log selection to the newest message,
+// following new ones from then on.
+func (a *App) onStatusLogBottom(_ *gocui.Gui, _ *gocui.View) error {
+	a.moveStatusLog(maxStatusHistory)
+	return nil
+}
+
+// onSearchStatusLog asks for text to search the status log for and
+// highlights the newest message containing it. An empty search clears it.
+func (a *App) onSearchStatusLog(g *gocui.Gui, _ *gocui.View) error {
+	if a.statusLog == nil {
+		return nil
+	}
+	a.askInput(g, "Search status log", a.statusLog.query, func(_ *gocui.Gui, query string) error {
+		if a.statusLog == nil {
+			return nil
+		}
+		a.statusLog.query = query
+		if query == "" || !a.findStatus(-1, true) {
+			a.drawStatusLog()
+		}
+		return nil
+	})
+	return nil
+}
+
+// findStatus moves the status log selection to the next message matching
+// the search in direction dir, starting from the newest message when
+// fromEnd is set. It reports whether a match was found.
+func (a *App) findStatus(dir int, fromEnd bool) bool {
+	s := a.statusLog
+	if s == nil || s.query == "" {
+		return false
+	}
+	entries := a.statusEntries()
+	i := s.selected + dir
+	if fromEnd {
+		i = len(entries) - 1
+	}
+	for ; i >= 0 && i < len(entries); i += dir {
+		if matchesStatus(entries[i], s.query) {
+			s.selected = i
+			s.follow = false
+			a.drawStatusLog()
+			return true
+		}
+	}
+	return false
+}
+
+// onStatusLogNext moves to the next older message matching the search.
+func (a *App) onStatusLogNext(_ *gocui.Gui, _ *gocui.View) error {
+	a.findStatus(-1, false)
+	return nil
+}
+
+// onStatusLogPrev moves to the next newer message matching the search.
+func (a *App) onStatusLogPrev(_ *gocui.Gui, _ *gocui.View) error {
+	a.findStatus(1, false)
+	return nil
+}
+
+// onCopyStatusLog copies the whole status history with timestamps to the
+// clipboard.
+func (a *App) onCopyStatusLog(_ *gocui.Gui, _ *gocui.View) error {
+	entries := a.statusEntries()
+	var sb strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "%s  %s\n", e.at.Format(time.DateTime), e.text)
+	}
+	if err := copyToClipboard(sb.String()); err != nil {
+		a.logf("Copy status log: %v", err)
+		return nil
+	}
+	a.logf("Copied %d status messages to clipboard", len(entries))
+	return nil
+}
+
+// onCloseStatusLog closes the status log overlay and returns focus to the
+// status pane, or the installed pane while the status pane is hidden.
+func (a *App) onCloseStatusLog(g *gocui.Gui, _ *gocui.View) error {
+	a.statusLog = nil
+	if err := g.DeleteView(viewStatusLog); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	focus := viewInstalled
+	if _, err := g.View(viewStatus); err == nil {
+		focus = viewStatus
+	}
+	_, err := g.SetCurrentView(focus)
+	return err
+}
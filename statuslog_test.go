@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestLogfConcurrent checks, under -race, that logf can be called from many
+// goroutines at once without a GUI, as background operations do, and that
+// no message is lost or reordered within its goroutine.
+func TestLogfConcurrent(t *testing.T) {
+	const writers, messages = 32, 100
+	a := newApp("")
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < messages; i++ {
+				a.logf("writer %d message %d", w, i)
+				if i%10 == 0 {
+					a.status()
+					a.statusEntries()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	entries := a.statusEntries()
+	if len(entries) != writers*messages {
+		t.Fatalf("got %d status entries, want %d", len(entries), writers*messages)
+	}
+	next := make([]int, writers)
+	for _, e := range entries {
+		var w, i int
+		if _, err := fmt.Sscanf(e.text, "writer %d message %d", &w, &i); err != nil {
+			t.Fatalf("unexpected entry %q: %v", e.text, err)
+		}
+		if i != next[w] {
+			t.Fatalf("writer %d logged message %d, want %d next", w, i, next[w])
+		}
+		next[w]++
+	}
+	if got := len(a.status()); got != statusPaneLines {
+		t.Errorf("status pane shows %d lines, want %d", got, statusPaneLines)
+	}
+}
+
+// TestLogfTrimsHistory checks that the history keeps only the most recent
+// maxStatusHistory messages.
+func TestLogfTrimsHistory(t *testing.T) {
+	a := newApp("")
+	for i := 0; i < maxStatusHistory+10; i++ {
+		a.logf("message %d", i)
+	}
+	entries := a.statusEntries()
+	if len(entries) != maxStatusHistory {
+		t.Fatalf("got %d status entries, want %d", len(entries), maxStatusHistory)
+	}
+	if got, want := entries[0].text, "message 10"; got != want {
+		t.Errorf("oldest entry %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// taskState is a step in the life of a task. Tasks start running, may wait
+// in the queue while the client throttles transfers, and end done, failed
+// or canceled.
+type taskState int
+
+// Task states.
+const (
+	taskRunning taskState = iota
+	taskQueued
+	taskDone
+	taskFailed
+	taskCanceled
+)
+
+// String returns the state as shown in the tasks pane.
+func (s taskState) String() string {
+	switch s {
+	case taskQueued:
+		return "queued"
+	case taskDone:
+		return "done"
+	case taskFailed:
+		return "failed"
+	case taskCanceled:
+		return "canceled"
+	}
+	return "running"
+}
+
+// active reports whether a task in this state has not finished yet.
+func (s taskState) active() bool {
+	return s == taskRunning || s == taskQueued
+}
+
+// maxFinishedTasks is the number of finished tasks kept in the tasks pane.
+const maxFinishedTasks = 20
+
+// task is an asynchronous request started from the UI, such as pulling,
+// deleting or preloading a model, or refreshing the lists.
+type task struct {
+	id       int                // Sequential ID, also stored in the task's context
+	desc     string             // Human-readable description, e.g. "load llama3"
+	state    taskState          // Current state
+	err      error              // Why the task failed, nil otherwise
+	quiet    bool               // Whether the task is routine, e.g. a refresh, and not worth confirming quit for
+	started  time.Time          // When the task started
+	finished time.Time          // When the task finished, zero while active
+	cancel   context.CancelFunc // Cancels the task's context
+	progress *transferProgress  // Latest transfer progress, nil unless reported
+}
+
+// taskKey is the context key under which a task's ID is stored.
+type taskKey struct{}
+
+// startTask registers a new running task and returns its context together
+// with a finish function that must be called with the task's result. The
+// context carries the task's ID for trackProgress.
+// It must be called from the GUI goroutine or before the main loop starts.
+func (a *App) startTask(desc string) (context.Context, func(error)) {
+	a.nextTaskID++
+	id := a.nextTaskID
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), taskKey{}, id))
+	t := &task{id: id, desc: desc, started: time.Now(), cancel: cancel}
+	a.tasks = append(a.tasks, t)
+	a.drawTasks()
+	return ctx, func(err error) {
+		cancel()
+		a.safeUpdate(func(_ *gocui.Gui) error {
+			a.finishTask(t, err)
+			return nil
+		})
+	}
+}
+
+// startQuietTask is startTask for routine tasks, which quitting does not ask
+// about.
+func (a *App) startQuietTask(desc string) (context.Context, func(error)) {
+	ctx, finish := a.startTask(desc)
+	a.tasks[len(a.tasks)-1].quiet = true
+	return ctx, finish
+}
+
+// finishTask records the result of t and drops the oldest finished tasks
+// beyond maxFinishedTasks.
+func (a *App) finishTask(t *task, err error) {
+	t.finished = time.Now()
+	switch {
+	case err == nil:
+		t.state = taskDone
+	case isCanceled(err):
+		t.state = taskCanceled
+	default:
+		t.state, t.err = taskFailed, err
+	}
+	finished := 0
+	for _, t := range a.tasks {
+		if !t.state.active() {
+			finished++
+		}
+	}
+	kept := a.tasks[:0]
+	for _, t := range a.tasks {
+		if !t.state.active() && finished > maxFinishedTasks {
+			finished--
+			continue
+		}
+		kept = append(kept, t)
+	}
+	if selected := a.taskSelected; selected < len(a.tasks) {
+		// Follow the selected task as older ones are dropped.
+		sel := a.tasks[selected]
+		a.taskSelected = 0
+		for i, t := range kept {
+			if t == sel {
+				a.taskSelected = i
+			}
+		}
+	}
+	a.tasks = kept
+	a.drawTasks()
+	a.drawProgress()
+}
+
+// taskByID returns the task with the given ID, or nil if it is gone.
+func (a *App) taskByID(id int) *task {
+	for _, t := range a.tasks {
+		if t.id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// activeTasks returns the tasks that have not finished, oldest first,
+// leaving out quiet ones unless all is set.
+func (a *App) activeTasks(all bool) []*task {
+	var active []*task
+	for _, t := range a.tasks {
+		if t.state.active() && (all || !t.quiet) {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// onCancelOp asks for confirmation and then cancels a task: the selected one
+// while the tasks pane has focus, otherwise the most recently started active
+// task, so that pressing it again cancels the next one.
+func (a *App) onCancelOp(g *gocui.Gui, v *gocui.View) error {
+	var t *task
+	if v != nil && v.Name() == viewTasks {
+		if t = a.selectedTask(); t == nil || !t.state.active() {
+			a.logf("Select a queued or running task to cancel")
+			return nil
+		}
+	} else {
+		active := a.activeTasks(true)
+		if len(active) == 0 {
+			a.logf("No operations running")
+			return nil
+		}
+		t = active[len(active)-1]
+	}
+	a.askConfirm(g, "Cancel "+t.desc+"?", func(*gocui.Gui) error {
+		t.cancel()
+		a.logf("Canceling %s...", t.desc)
+		return nil
+	})
+	return nil
+}
+
+// selectedTask returns the task selected in the tasks pane, or nil if there
+// are no tasks.
+func (a *App) selectedTask() *task {
+	if len(a.tasks) == 0 {
+		return nil
+	}
+	a.clampTaskSelection()
+	return a.tasks[a.taskSelected]
+}
+
+// clampTaskSelection keeps the task selection within the task list.
+func (a *App) clampTaskSelection() {
+	if a.taskSelected >= len(a.tasks) {
+		a.taskSelected = len(a.tasks) - 1
+	}
+	if a.taskSelected < 0 {
+		a.taskSelected = 0
+	}
+}
+
+// onTaskUp moves the task selection one row up.
+func (a *App) onTaskUp(_ *gocui.Gui, _ *gocui.View) error {
+	if a.taskSelected > 0 {
+		a.taskSelected--
+		a.drawTasks()
+	}
+	return nil
+}
+
+// onTaskDown moves the task selection one row down.
+func (a *App) onTaskDown(_ *gocui.Gui, _ *gocui.View) error {
+	if a.taskSelected < len(a.tasks)-1 {
+		a.taskSelected++
+		a.drawTasks()
+	}
+	return nil
+}
+
+// isCanceled reports whether err is the result of canceling an operation.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
+
+// cancelTasks cancels every active task.
+func (a *App) cancelTasks() {
+	for _, t := range a.activeTasks(true) {
+		t.cancel()
+	}
+}
+
+// tasksSummary returns a one-line count of the tasks by state, e.g.
+// "1 running · 2 queued · 1 failed".
+func (a *App) tasksSummary() string {
+	counts := make(map[taskState]int)
+	for _, t := range a.tasks {
+		counts[t.state]++
+	}
+	var parts []string
+	for _, s := range []taskState{taskRunning, taskQueued, taskFailed, taskCanceled, taskDone} {
+		if n := counts[s]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, s))
+		}
+	}
+	if len(parts) == 0 {
+		return "(no tasks)"
+	}
+	return strings.Join(parts, " · ")
+}
+
+// taskLine formats a task row: its state, description and, depending on the
+// state, its progress, error or duration.
+func (a *App) taskLine(t *task, now time.Time) string {
+	var mark, detail string
+	switch t.state {
+	case taskRunning, taskQueued:
+		mark = a.theme.paint(a.theme.accent, "●")
+		detail = now.Sub(t.started).Round(time.Second).String()
+		if t.progress != nil {
+			detail = t.progress.line()
+		}
+	case taskDone:
+		mark = "✓"
+		detail = t.finished.Sub(t.started).Round(100 * time.Millisecond).String()
+	case taskFailed:
+		mark = a.theme.paint(a.theme.alert, "✗")
+		detail = a.theme.paint(a.theme.alert, t.err.Error())
+	case taskCanceled:
+		mark = "-"
+	}
+	return fmt.Sprintf("%s %-8s %s  %s", mark, t.state, fitWidth(t.desc, 28), detail)
+}
+
+// tasksHeight returns the height of the tasks pane: a single summary row
+// while collapsed, otherwise one row per task, up to half of maxH.
+func (a *App) tasksHeight(maxH int) int {
+	if !a.tasksExpanded {
+		return 3
+	}
+	h := len(a.tasks) + 2
+	if h > maxH/2 {
+		h = maxH / 2
+	}
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+// tasksTitle returns the tasks pane title with the keys it offers.
+func (a *App) tasksTitle() string {
+	if a.tasksExpanded {
+		return "Tasks (x cancel, A collapse)"
+	}
+	return "Tasks (A expand)"
+}
+
+// drawTasks renders the tasks pane: the summary while collapsed, otherwise
+// one row per task, newest last, with the selection highlighted while the
+// pane has focus.
+func (a *App) drawTasks() {
+	a.safeUpdate(func(g *gocui.Gui) error {
+		v, err := g.View(viewTasks)
+		if err != nil {
+			return nil
+		}
+		v.Title = a.tasksTitle()
+		v.Clear()
+		if !a.tasksExpanded || len(a.tasks) == 0 {
+			v.Highlight = false
+			fmt.Fprint(v, a.tasksSummary())
+			return v.SetOrigin(0, 0)
+		}
+		now := time.Now()
+		for _, t := range a.tasks {
+			fmt.Fprintln(v, a.taskLine(t, now))
+		}
+		a.clampTaskSelection()
+		v.Highlight = g.CurrentView() == v
+		return showRow(v, a.taskSelected)
+	})
+}
+
+// onToggleTasks expands or collapses the tasks pane. Expanding selects the
+// newest task; collapsing the focused pane moves focus to the running pane.
+func (a *App) onToggleTasks(g *gocui.Gui, v *gocui.View) error {
+	a.tasksExpanded = !a.tasksExpanded
+	if a.tasksExpanded {
+		a.taskSelected = len(a.tasks) - 1
+	} else if v != nil && v.Name() == viewTasks {
+		if _, err := g.SetCurrentView(viewRunning); err != nil {
+			return err
+		}
+		a.drawRunning()
+	}
+	a.drawTasks()
+	a.drawProgress()
+	return nil
+}
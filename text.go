@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// displayName makes a model name safe to render in a single terminal row by
+// replacing control characters (which could inject escape sequences or line
+// breaks) with U+FFFD.
+func displayName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return '�'
+		}
+		return r
+	}, name)
+}
+
+// fitWidth pads s with spaces to exactly width terminal cells, truncating it
+// with an ellipsis when it is wider. Widths are measured in cells, so wide
+// (e.g. CJK) characters keep columns aligned.
+func fitWidth(s string, width int) string {
+	w := runewidth.StringWidth(s)
+	if w > width {
+		return runewidth.Truncate(s, width, "…")
+	}
+	return s + strings.Repeat(" ", width-w)
+}
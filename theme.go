@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jroimartin/gocui"
+)
+
+// theme describes the colors and text attributes used to render the GUI.
+// A theme with colors disabled must only use gocui.ColorDefault plus style
+// attributes, so it renders correctly on terminals without color support.
+type theme struct {
+	name   string          // Theme identifier as used in configuration
+	colors bool            // Whether color attributes and ANSI escapes may be emitted
+	selFg  gocui.Attribute // Foreground of the focused view's frame and title
+	selBg  gocui.Attribute // Background of the focused view's frame and title
+	rowFg  gocui.Attribute // Foreground of the selected row in a list
+	rowBg  gocui.Attribute // Background of the selected row in a list
+	accent string          // ANSI SGR parameters used for accented text
+	alert  string          // ANSI SGR parameters used for warnings and errors
+}
+
+// Built-in themes. The mono theme never emits color attributes.
+var (
+	themeDefault = theme{
+		name:   "default",
+		colors: true,
+		selFg:  gocui.ColorGreen | gocui.AttrBold,
+		selBg:  gocui.ColorDefault,
+		rowFg:  gocui.ColorBlack,
+		rowBg:  gocui.ColorGreen,
+		accent: "36",
+		alert:  "31",
+	}
+	themeMono = theme{
+		name:   "mono",
+		colors: false,
+		selFg:  gocui.ColorDefault | gocui.AttrBold,
+		selBg:  gocui.ColorDefault,
+		rowFg:  gocui.ColorDefault | gocui.AttrReverse,
+		rowBg:  gocui.ColorDefault,
+		accent: "1",
+		alert:  "1",
+	}
+)
+
+// themeByName returns the built-in theme with the given name.
+// Unknown names fall back to the default theme.
+func themeByName(name string) theme {
+	if name == themeMono.name {
+		return themeMono
+	}
+	return themeDefault
+}
+
+// colorSupported reports whether the terminal is expected to render colors.
+// It follows the NO_COLOR convention and inspects TERM and COLORTERM.
+func colorSupported() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("COLORTERM") != "" {
+		return true
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case term == "", term == "dumb":
+		return false
+	case strings.HasSuffix(term, "-m"), strings.HasSuffix(term, "-mono"):
+		return false
+	}
+	return true
+}
+
+// paint wraps s in the given ANSI SGR parameters.
+// The mono theme only defines style parameters, so no colors are emitted.
+func (t theme) paint(sgr, s string) string {
+	if sgr == "" || s == "" {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
+
+// applyTheme configures the GUI-level colors from the current theme.
+func (a *App) applyTheme() {
+	if a.gui == nil {
+		return
+	}
+	a.gui.Highlight = true
+	a.gui.SelFgColor = a.theme.selFg
+	a.gui.SelBgColor = a.theme.selBg
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// onCountTokens prompts for text and shows how the selected installed model
+// tokenizes it, for budgeting prompts against the context length.
+func (a *App) onCountTokens(g *gocui.Gui, _ *gocui.View) error {
+	m := a.selectedModel()
+	if m == nil {
+		return nil
+	}
+	name := m.Name
+	a.askInput(g, "Count tokens with "+displayName(name), "", func(g *gocui.Gui, text string) error {
+		if text != "" {
+			a.countTokens(name, text)
+		}
+		return nil
+	})
+	return nil
+}
+
+// countTokens tokenizes text in a background goroutine and shows the result,
+// checked by detokenizing it again, in the info overlay.
+func (a *App) countTokens(name, text string) {
+	client := a.client
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		tokens, err := client.Tokenize(ctx, name, text)
+		var roundTrip string
+		if err == nil {
+			roundTrip, err = client.Detokenize(ctx, name, tokens)
+		}
+		a.safeUpdate(func(g *gocui.Gui) error {
+			switch {
+			case errors.Is(err, ollama.ErrNotSupported):
+				a.logf("Tokenization is not available on this server")
+				return nil
+			case err != nil:
+				metricErrors.Add(1)
+				a.logErr("Tokenize with "+name, err)
+				return nil
+			}
+			a.logf("%s: %d tokens", displayName(name), len(tokens))
+			return a.showInfo(g, "Tokens: "+displayName(name), formatTokens(text, tokens, roundTrip))
+		})
+	}()
+}
+
+// formatTokens summarizes a tokenization: the token count, characters per
+// token, the leading token IDs and whether detokenizing restores the text.
+func formatTokens(text string, tokens []int, roundTrip string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Text:        %q\n", text)
+	fmt.Fprintf(&b, "Tokens:      %d\n", len(tokens))
+	if len(tokens) > 0 {
+		fmt.Fprintf(&b, "Chars/token: %.2f\n", float64(len([]rune(text)))/float64(len(tokens)))
+	}
+	b.WriteString("IDs:        ")
+	for i, t := range tokens {
+		if i == 64 {
+			fmt.Fprintf(&b, " … (%d more)", len(tokens)-i)
+			break
+		}
+		fmt.Fprintf(&b, " %d", t)
+	}
+	b.WriteString("\n")
+	if roundTrip == text {
+		b.WriteString("Round trip:  detokenizing restores the text\n")
+	} else {
+		fmt.Fprintf(&b, "Round trip:  %q\n", roundTrip)
+	}
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// updateState records the result of comparing a local model against its registry.
+type updateState int
+
+const (
+	updateUnchecked updateState = iota // Not checked yet
+	updateCurrent                      // Local digest matches the registry
+	updateAvailable                    // Registry has a newer manifest
+	updateUnknown                      // Remote digest could not be fetched
+)
+
+// updateBadge returns the marker shown next to a model in the installed pane.
+func (a *App) updateBadge(name string) string {
+	switch a.updates[name] {
+	case updateAvailable:
+		return a.theme.paint(a.theme.accent, "update available")
+	case updateUnknown:
+		return "update unknown"
+	default:
+		return ""
+	}
+}
+
+// onCheckUpdates compares every installed model against its registry
+// manifest in a background goroutine and marks those with a newer remote
+// version. Models whose remote digest can't be fetched, such as locally
+// created ones, are marked unknown.
+func (a *App) onCheckUpdates(_ *gocui.Gui, _ *gocui.View) error {
+	models := append([]ollama.Model(nil), a.installed...)
+	if len(models) == 0 {
+		a.logf("No models to check")
+		return nil
+	}
+	a.logf("Checking %d models for updates...", len(models))
+	client, gen := a.client, a.hostGen
+	go func() {
+		results := make(map[string]updateState, len(models))
+		for _, m := range models {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			remote, err := client.RemoteDigest(ctx, m.Name)
+			cancel()
+			switch {
+			case err != nil:
+				results[m.Name] = updateUnknown
+			case ollama.SameDigest(remote, m.Digest):
+				results[m.Name] = updateCurrent
+			default:
+				results[m.Name] = updateAvailable
+			}
+		}
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if gen != a.hostGen {
+				return nil
+			}
+			available, unknown := 0, 0
+			for name, st := range results {
+				a.updates[name] = st
+				switch st {
+				case updateAvailable:
+					available++
+				case updateUnknown:
+					unknown++
+				}
+			}
+			a.drawInstalled()
+			a.logf("Updates: %d available, %d unknown", available, unknown)
+			return nil
+		})
+	}()
+	return nil
+}
+
+// outdatedModels returns the names of installed models marked as having an
+// update available, in display order.
+func (a *App) outdatedModels() []string {
+	var names []string
+	for _, idx := range a.order {
+		if name := a.installed[idx].Name; a.updates[name] == updateAvailable {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// onPullOutdated re-pulls every model the last update check found outdated,
+// after confirmation. The client's transfer limit queues the pulls.
+func (a *App) onPullOutdated(g *gocui.Gui, _ *gocui.View) error {
+	names := a.outdatedModels()
+	if len(names) == 0 {
+		a.logf("No outdated models (press U to check for updates)")
+		return nil
+	}
+	msg := fmt.Sprintf("Pull updates for %d models (%s)?", len(names), strings.Join(names, ", "))
+	if len(names) == 1 {
+		msg = "Pull the update for " + displayName(names[0]) + "?"
+	}
+	a.askConfirm(g, msg, func(*gocui.Gui) error {
+		for _, name := range names {
+			a.pull(name)
+		}
+		return nil
+	})
+	return nil
+}
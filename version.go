@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// checkVersion fetches the server version in a background goroutine and warns
+// once about every feature the server is too old to support.
+func (a *App) checkVersion() {
+	client, gen := a.client, a.hostGen
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		version, err := client.Version(ctx)
+		a.safeUpdate(func(g *gocui.Gui) error {
+			if gen != a.hostGen {
+				return nil
+			}
+			a.versionErr = err
+			a.updateStatusTitle(g)
+			if err != nil {
+				return nil
+			}
+			a.serverVersion = version
+			if !ollama.SupportsFeature(version, ollama.FeatureRunningDetails) && a.runningDetailed {
+				a.runningDetailed = false
+				a.drawRunning()
+			}
+			for _, f := range []ollama.Feature{ollama.FeatureRunningDetails} {
+				if !ollama.SupportsFeature(version, f) {
+					a.warnUnsupported(f)
+				}
+			}
+			return nil
+		})
+	}()
+}
+
+// statusTitle returns the status pane title: a connection indicator with
+// the server and its version, or a note that it could not be reached,
+// followed by the auto-refresh countdown while connected.
+func (a *App) statusTitle() string {
+	switch {
+	case a.offline:
+		return fmt.Sprintf("Status — ○ disconnected from %s", a.baseURL)
+	case a.versionErr != nil:
+		return fmt.Sprintf("Status — %s (unreachable)", a.baseURL)
+	}
+	title := "Status — " + a.baseURL
+	if a.serverVersion != "" {
+		title = fmt.Sprintf("Status — ● Ollama %s at %s", a.serverVersion, a.baseURL)
+	}
+	if countdown := a.refreshCountdown(); countdown != "" {
+		title += " — " + countdown
+	}
+	return title
+}
+
+// updateStatusTitle applies statusTitle to the status pane.
+func (a *App) updateStatusTitle(g *gocui.Gui) {
+	if v, err := g.View(viewStatus); err == nil {
+		v.Title = a.statusTitle()
+	}
+}
+
+// supports reports whether the connected server supports f. Until the server
+// version is known, every feature is assumed to be supported.
+func (a *App) supports(f ollama.Feature) bool {
+	return a.serverVersion == "" || ollama.SupportsFeature(a.serverVersion, f)
+}
+
+// requireFeature reports whether f is supported, logging a warning otherwise.
+// Actions that depend on f call it before doing anything.
+func (a *App) requireFeature(f ollama.Feature) bool {
+	if a.supports(f) {
+		return true
+	}
+	a.warnUnsupported(f)
+	return false
+}
+
+// warnUnsupported logs that the server is too old for f, once per feature.
+func (a *App) warnUnsupported(f ollama.Feature) {
+	if a.warned[f] {
+		return
+	}
+	a.warned[f] = true
+	a.logf("Warning: server %s does not support %s (needs %s)", a.serverVersion, f, f.MinVersion())
+}
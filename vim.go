@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jroimartin/gocui"
+)
+
+// Key binding sets selectable with Config.Keymap.
+const (
+	keymapDefault = ""    // Arrows and single letters
+	keymapVim     = "vim" // Adds h/l pane switching, gg/G and dd on top of the default
+)
+
+// chordTimeout is how long the first key of a two-key vim command waits for
+// the second.
+const chordTimeout = time.Second
+
+// maxJump moves a selection far enough to reach either end of any list.
+const maxJump = 1 << 30
+
+// pendingChord is the first key of a two-key vim command.
+type pendingChord struct {
+	key  rune      // Key pressed first
+	view string    // View it was pressed in
+	at   time.Time // When it was pressed
+}
+
+// validateKeymap checks that name is a known key binding set.
+func validateKeymap(name string) error {
+	switch name {
+	case keymapDefault, keymapVim:
+		return nil
+	}
+	return fmt.Errorf("keymap: unknown keymap %q (want %q)", name, keymapVim)
+}
+
+// chord returns a binding of key in view running fn when key is pressed
+// twice in a row in that view within chordTimeout, as in vim's dd and gg.
+// fn is recorded under desc so the command palette can run it directly.
+func (a *App) chord(view string, key rune, fn func(*gocui.Gui, *gocui.View) error, desc string) binding {
+	if a.chordActions == nil {
+		a.chordActions = make(map[string]func(*gocui.Gui, *gocui.View) error)
+	}
+	a.chordActions[desc] = fn
+	return binding{view, key, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		name := ""
+		if v != nil {
+			name = v.Name()
+		}
+		p := a.pending
+		if p.key == key && p.view == name && time.Since(p.at) < chordTimeout {
+			a.pending = pendingChord{}
+			return fn(g, v)
+		}
+		a.pending = pendingChord{key: key, view: name, at: time.Now()}
+		return nil
+	}, desc}
+}
+
+// jump returns a handler moving a selection with move to the first (dir -1)
+// or last (dir 1) row.
+func jump(move func(n int), dir int) func(*gocui.Gui, *gocui.View) error {
+	return func(_ *gocui.Gui, _ *gocui.View) error {
+		move(dir * maxJump)
+		return nil
+	}
+}
+
+// moveTasks moves the task selection by n rows, stopping at either end.
+func (a *App) moveTasks(n int) {
+	a.taskSelected += n
+	a.clampTaskSelection()
+	a.drawTasks()
+}
+
+// vimBindings applies the vim keymap to table: bindings the keymap takes
+// over are moved to other keys or replaced, and its own are appended.
+func (a *App) vimBindings(table []binding) []binding {
+	type scopedKey struct {
+		view string
+		key  any
+	}
+	// Keys taken over by vim commands, and where their default action moves.
+	moved := map[scopedKey]any{
+		{viewInstalled, 'g'}: 'F',
+		{viewInstalled, 'd'}: nil,
+		{viewStatusLog, 'g'}: nil,
+	}
+	var out []binding
+	for _, b := range table {
+		if to, ok := moved[scopedKey{b.view, b.key}]; ok {
+			if to == nil {
+				continue
+			}
+			b.key = to
+		}
+		out = append(out, b)
+	}
+	return append(out,
+		binding{"", 'h', gocui.ModNone, a.onFocusPrev, "previous pane"},
+		binding{"", 'l', gocui.ModNone, a.onFocusNext, "switch pane"},
+
+		a.chord(viewInstalled, 'g', jump(a.moveInstalled, -1), "first (gg)"),
+		binding{viewInstalled, 'G', gocui.ModNone, jump(a.moveInstalled, 1), "last"},
+		a.chord(viewInstalled, 'd', a.onDelete, "delete (dd)"),
+
+		a.chord(viewRunning, 'g', jump(a.moveRunning, -1), "first (gg)"),
+		binding{viewRunning, 'G', gocui.ModNone, jump(a.moveRunning, 1), "last"},
+
+		a.chord(viewTasks, 'g', jump(a.moveTasks, -1), "first (gg)"),
+		binding{viewTasks, 'G', gocui.ModNone, jump(a.moveTasks, 1), "last"},
+
+		a.chord(viewStatusLog, 'g', jump(a.moveStatusLog, -1), "oldest (gg)"),
+	)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"olazyllama/internal/ollama"
+)
+
+// runWatch repeatedly clears the terminal and prints a plain-text dashboard of
+// installed and running models every interval, until interrupted with Ctrl+C.
+// It is an alternative to the TUI for terminals where gocui misbehaves.
+func (a *App) runWatch(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("refresh interval must be positive, got %v", interval)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		installed, running, err1, err2 := fetch(ctx, a.client)
+		if ctx.Err() != nil {
+			return nil
+		}
+		recordRefresh(refreshResult{installed: installed, installedErr: err1, runningErr: err2})
+		os.Stdout.Write(a.watchFrame(installed, running, err1, err2, time.Now()))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchFrame renders one frame of the watch dashboard, prefixed with the
+// escape sequence that clears the screen and homes the cursor.
+func (a *App) watchFrame(installed, running []ollama.Model, installedErr, runningErr error, now time.Time) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H\x1b[2J")
+	fmt.Fprintf(&buf, "olazyllama — %s — %s\n\n", a.baseURL, now.Format("15:04:05"))
+
+	buf.WriteString(a.theme.paint(a.theme.accent, "Installed Models") + "\n")
+	switch {
+	case installedErr != nil:
+		buf.WriteString(a.theme.paint(a.theme.alert, "⚠ failed to load installed models: "+installedErr.Error()) + "\n")
+	case len(installed) == 0:
+		buf.WriteString("(no models installed)\n")
+	}
+	for _, m := range installed {
+		buf.WriteString(a.installedLine(m) + "\n")
+	}
+
+	buf.WriteString("\n" + a.theme.paint(a.theme.accent, "Running") + "\n")
+	switch {
+	case runningErr != nil:
+		buf.WriteString(a.theme.paint(a.theme.alert, "⚠ failed to load running models: "+runningErr.Error()) + "\n")
+	case len(running) == 0:
+		buf.WriteString("(nothing running)\n")
+	default:
+		buf.WriteString(runningHeader() + "\n")
+	}
+	for _, m := range running {
+		buf.WriteString(runningLine(m, now) + "\n")
+	}
+	return buf.Bytes()
+}
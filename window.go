@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/jroimartin/gocui"
+)
+
+// listWindow is the visible part of a virtualized list. Only the rows that
+// fit in the view are rendered, so drawing a long list costs no more than a
+// short one; the view itself never scrolls.
+type listWindow struct {
+	top    int // First row shown
+	height int // Number of rows the view had room for at the last draw
+}
+
+// scrollWindow returns the first row to show in a window of height rows
+// over a list of total rows, moving it from top as little as possible to
+// keep row visible. The window does not extend past the end of the list, so
+// a list that shrinks scrolls back rather than leaving blank rows.
+func scrollWindow(top, row, height, total int) int {
+	if height <= 0 {
+		return 0
+	}
+	if row < top {
+		top = row
+	}
+	if row >= top+height {
+		top = row - height + 1
+	}
+	if top > total-height {
+		top = total - height
+	}
+	if top < 0 {
+		top = 0
+	}
+	return top
+}
+
+// visibleRows returns the half-open range of rows shown in a window of
+// height rows starting at top over a list of total rows. It is empty when
+// the view has no room.
+func visibleRows(top, height, total int) (start, end int) {
+	if height < 0 {
+		height = 0
+	}
+	start, end = top, top+height
+	if end > total {
+		end = total
+	}
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+// draw renders the rows of a list of total rows that fit in v into it,
+// using buf as scratch space, and highlights the cursor row. line appends
+// row i to buf, without a newline.
+func (w *listWindow) draw(v *gocui.View, buf *bytes.Buffer, total, cursor int, line func(buf *bytes.Buffer, i int)) error {
+	_, w.height = v.Size()
+	w.top = scrollWindow(w.top, cursor, w.height, total)
+	start, end := visibleRows(w.top, w.height, total)
+	buf.Reset()
+	for i := start; i < end; i++ {
+		line(buf, i)
+		buf.WriteByte('\n')
+	}
+	v.Clear()
+	v.Write(buf.Bytes())
+	if err := v.SetOrigin(0, 0); err != nil {
+		return err
+	}
+	return v.SetCursor(0, cursor-w.top)
+}
+
+// resized reports whether v has changed height since the window was last
+// drawn or checked, in which case it needs drawing again to fill the view
+// and keep the cursor row visible.
+func (w *listWindow) resized(v *gocui.View) bool {
+	_, h := v.Size()
+	if h == w.height {
+		return false
+	}
+	w.height = h
+	return true
+}
@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+// TestScrollWindow checks that the window follows the cursor with as little
+// scrolling as possible and never extends past the end of the list.
+func TestScrollWindow(t *testing.T) {
+	tests := []struct {
+		name                    string
+		top, row, height, total int
+		want                    int
+	}{
+		{"cursor inside", 5, 7, 10, 100, 5},
+		{"cursor on first row", 5, 5, 10, 100, 5},
+		{"cursor on last row", 5, 14, 10, 100, 5},
+		{"cursor above", 20, 3, 10, 100, 3},
+		{"cursor just above", 20, 19, 10, 100, 19},
+		{"cursor below", 0, 50, 10, 100, 41},
+		{"cursor just below", 0, 10, 10, 100, 1},
+		{"cursor at end", 0, 99, 10, 100, 90},
+		{"list shrank under window", 90, 20, 10, 25, 15},
+		{"list shrank past window", 90, 4, 10, 5, 0},
+		{"list emptied", 40, 0, 10, 0, 0},
+		{"list shorter than window", 3, 2, 10, 6, 0},
+		{"window grew", 80, 95, 30, 100, 70},
+		{"zero height", 5, 7, 0, 100, 0},
+		{"negative height", 5, 7, -3, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scrollWindow(tt.top, tt.row, tt.height, tt.total)
+			if got != tt.want {
+				t.Errorf("scrollWindow(%d, %d, %d, %d) = %d, want %d", tt.top, tt.row, tt.height, tt.total, got, tt.want)
+			}
+			if tt.height > 0 && tt.total > 0 && (tt.row < got || tt.row >= got+tt.height) {
+				t.Errorf("row %d is outside the window [%d, %d)", tt.row, got, got+tt.height)
+			}
+		})
+	}
+}
+
+// TestVisibleRows checks the range of rows drawn for a window, including
+// windows that reach past the end of the list or have no height.
+func TestVisibleRows(t *testing.T) {
+	tests := []struct {
+		name               string
+		top, height, total int
+		start, end         int
+	}{
+		{"full window", 5, 10, 100, 5, 15},
+		{"window at end", 90, 10, 100, 90, 100},
+		{"window past end", 95, 10, 100, 95, 100},
+		{"top past end", 120, 10, 100, 100, 100},
+		{"list shorter than window", 0, 10, 4, 0, 4},
+		{"empty list", 0, 10, 0, 0, 0},
+		{"zero height", 5, 0, 100, 5, 5},
+		{"negative height", 5, -2, 100, 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := visibleRows(tt.top, tt.height, tt.total)
+			if start != tt.start || end != tt.end {
+				t.Errorf("visibleRows(%d, %d, %d) = [%d, %d), want [%d, %d)", tt.top, tt.height, tt.total, start, end, tt.start, tt.end)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+
+	"olazyllama/internal/ollama"
+)
+
+// selectedIn returns the model selected in v, the installed or running pane.
+func (a *App) selectedIn(v *gocui.View) *ollama.Model {
+	if v != nil && v.Name() == viewRunning {
+		return a.selectedRunning()
+	}
+	return a.selectedModel()
+}
+
+// yank copies what of the model selected in v, as returned by field, to the
+// clipboard.
+func (a *App) yank(v *gocui.View, what string, field func(*ollama.Model) string) {
+	m := a.selectedIn(v)
+	if m == nil {
+		return
+	}
+	text := field(m)
+	if text == "" {
+		a.logf("%s has no %s to copy", displayName(m.Name), what)
+		return
+	}
+	if err := copyToClipboard(text); err != nil {
+		a.logErr("Copy "+what, err)
+		return
+	}
+	a.logf("Copied %s %s to clipboard", what, text)
+}
+
+// onYankName copies the selected model's full name to the clipboard.
+func (a *App) onYankName(_ *gocui.Gui, v *gocui.View) error {
+	a.yank(v, "name", func(m *ollama.Model) string { return m.Name })
+	return nil
+}
+
+// onYankDigest copies the selected model's digest to the clipboard.
+func (a *App) onYankDigest(_ *gocui.Gui, v *gocui.View) error {
+	a.yank(v, "digest", func(m *ollama.Model) string { return m.Digest })
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"github.com/jroimartin/gocui"
+)
+
+// zoomable lists the views 'z' can expand to fill the screen.
+var zoomable = map[string]bool{
+	viewInstalled: true,
+	viewRunning:   true,
+	viewTasks:     true,
+	viewStatus:    true,
+	viewDetails:   true,
+	viewLogs:      true,
+	viewInfo:      true,
+}
+
+// setView is gocui.Gui.SetView for views that can be zoomed: while the view
+// name is zoomed it fills the screen above the key legend instead.
+func (a *App) setView(g *gocui.Gui, name string, x0, y0, x1, y1 int) (*gocui.View, error) {
+	if a.zoomed == name {
+		maxX, maxY := g.Size()
+		x0, y0, x1, y1 = 0, 0, maxX-1, maxY-2
+	}
+	return g.SetView(name, x0, y0, x1, y1)
+}
+
+// layoutZoom raises the zoomed view over the other panes, and ends the zoom
+// once that view has been closed or hidden.
+func (a *App) layoutZoom(g *gocui.Gui) error {
+	if a.zoomed == "" {
+		return nil
+	}
+	if _, err := g.View(a.zoomed); err != nil {
+		a.zoomed = ""
+		return nil
+	}
+	_, err := g.SetViewOnTop(a.zoomed)
+	return err
+}
+
+// onToggleZoom expands the focused pane or overlay to fill the screen, or
+// restores the layout if it already does. The panes are redrawn to keep
+// their selection in view at the new size.
+func (a *App) onToggleZoom(_ *gocui.Gui, v *gocui.View) error {
+	switch {
+	case v == nil || !zoomable[v.Name()]:
+		return nil
+	case a.zoomed == v.Name():
+		a.zoomed = ""
+	default:
+		a.zoomed = v.Name()
+	}
+	a.drawInstalled()
+	a.drawRunning()
+	a.drawTasks()
+	return nil
+}